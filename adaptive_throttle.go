@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// adaptiveThrottle spaces sends by a delay that grows while the server is
+// failing and shrinks again as it recovers, see [WithAdaptiveThrottle]. The
+// delay doubles on every server failure, up to maxDelay, and decreases by
+// baseDelay on every success, down to zero.
+type adaptiveThrottle struct {
+	mu        sync.Mutex
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	delay     time.Duration
+	last      time.Time
+}
+
+func newAdaptiveThrottle(baseDelay, maxDelay time.Duration) *adaptiveThrottle {
+	return &adaptiveThrottle{
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+	}
+}
+
+// wait blocks until the current delay has passed since the previous send, or
+// ctx is done.
+func (t *adaptiveThrottle) wait(ctx context.Context) error {
+	wait := t.reserve()
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve claims the next send slot and returns how long the caller must
+// wait for it.
+func (t *adaptiveThrottle) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+
+	slot := t.last.Add(t.delay)
+	if slot.Before(now) {
+		slot = now
+	}
+
+	t.last = slot
+
+	return slot.Sub(now)
+}
+
+// record adjusts the delay to the status code of the response to a send.
+func (t *adaptiveThrottle) record(statusCode int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if isServerFailure(statusCode) {
+		t.delay = min(t.maxDelay, max(t.baseDelay, 2*t.delay))
+	} else {
+		t.delay = max(0, t.delay-t.baseDelay)
+	}
+}
+
+// currentDelay returns the delay currently applied between sends.
+func (t *adaptiveThrottle) currentDelay() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.delay
+}
+
+// isServerFailure reports whether statusCode indicates a struggling server
+// rather than a rejected request.
+func isServerFailure(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
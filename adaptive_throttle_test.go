@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestAdaptiveThrottle_Record(t *testing.T) {
+	t.Parallel()
+
+	throttle := newAdaptiveThrottle(10*time.Millisecond, 50*time.Millisecond)
+
+	steps := []struct {
+		statusCode int
+		expected   time.Duration
+	}{
+		{http.StatusOK, 0},
+		{http.StatusServiceUnavailable, 10 * time.Millisecond},
+		{http.StatusGatewayTimeout, 20 * time.Millisecond},
+		{http.StatusTooManyRequests, 40 * time.Millisecond},
+		{http.StatusBadGateway, 50 * time.Millisecond},
+		{http.StatusBadRequest, 40 * time.Millisecond},
+		{http.StatusOK, 30 * time.Millisecond},
+		{http.StatusOK, 20 * time.Millisecond},
+	}
+
+	for i, step := range steps {
+		throttle.record(step.statusCode)
+
+		if got := throttle.currentDelay(); got != step.expected {
+			t.Fatalf("step %d (status %d): expected delay %v, got %v", i, step.statusCode, step.expected, got)
+		}
+	}
+}
+
+func TestAdaptiveThrottle_Wait(t *testing.T) {
+	t.Parallel()
+
+	throttle := newAdaptiveThrottle(20*time.Millisecond, time.Second)
+
+	if wait := throttle.reserve(); wait != 0 {
+		t.Errorf("expected no wait without failures, got %v", wait)
+	}
+
+	throttle.record(http.StatusInternalServerError)
+
+	if wait := throttle.reserve(); wait <= 0 || wait > 20*time.Millisecond {
+		t.Errorf("expected a wait of up to 20ms after a failure, got %v", wait)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	throttle.record(http.StatusInternalServerError)
+
+	if err := throttle.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClient_AdaptiveThrottle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithAdaptiveThrottle(20*time.Millisecond, time.Second), WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	_ = c.Send(context.Background(), &types.Alert{})
+
+	start := time.Now()
+	_ = c.Send(context.Background(), &types.Alert{})
+
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected the second send to be throttled, took %v", elapsed)
+	}
+
+	if got := c.throttle.currentDelay(); got != 40*time.Millisecond {
+		t.Errorf("expected delay 40ms after two failures, got %v", got)
+	}
+}
+
+func TestClient_AdaptiveThrottle_IgnoresRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithAdaptiveThrottle(20*time.Millisecond, time.Second), WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	// A send cancelled by the caller never reaches the server
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := c.Send(ctx, &types.Alert{}); err == nil {
+		t.Fatal("expected the cancelled send to fail")
+	}
+
+	// Neither does a send that cannot dial
+	server.Close()
+
+	if err := c.Send(context.Background(), &types.Alert{}); err == nil {
+		t.Fatal("expected the send to a closed server to fail")
+	}
+
+	if got := c.throttle.currentDelay(); got != 0 {
+		t.Errorf("expected request errors to leave the delay at 0, got %v", got)
+	}
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/slackmgr/types"
+)
+
+// defaultAlertKey is the default identity of an alert, see [WithAlertKey]:
+// the hex-encoded SHA-256 of its header, text and severity.
+func defaultAlertKey(alert *types.Alert) string {
+	h := sha256.New()
+
+	for _, field := range []string{alert.Header, alert.Text, string(alert.Severity)} {
+		// The NUL separator keeps e.g. header "ab" with text "c" apart from
+		// header "a" with text "bc".
+		_, _ = h.Write([]byte(field))
+		_, _ = h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestDefaultAlertKey(t *testing.T) {
+	t.Parallel()
+
+	base := &types.Alert{Header: "ab", Text: "c", Severity: types.AlertError, CorrelationID: "1"}
+	key := defaultAlertKey(base)
+
+	if same := defaultAlertKey(&types.Alert{Header: "ab", Text: "c", Severity: types.AlertError, CorrelationID: "2"}); same != key {
+		t.Errorf("expected fields other than header, text and severity to be ignored, got %s and %s", key, same)
+	}
+
+	others := []*types.Alert{
+		{Header: "a", Text: "bc", Severity: types.AlertError},
+		{Header: "ab", Text: "c", Severity: types.AlertWarning},
+		{Header: "ab", Text: "d", Severity: types.AlertError},
+		{Header: "abc", Severity: types.AlertError},
+	}
+
+	for i, other := range others {
+		if defaultAlertKey(other) == key {
+			t.Errorf("expected alert %d to have a different key", i)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// prepareAlerts applies the alert transformations configured through options
+// before the alerts are marshaled. Alerts are cloned before being modified and
+// the slice is copied before being reordered, so the caller's values are never
+// mutated. When no transformation is configured the input slice is returned
+// as-is. An error is returned for an alert with more labels than allowed by
+// [WithMaxLabelsPerAlert], unless [WithTruncateExcessLabels] is enabled.
+func (c *Client) prepareAlerts(alerts []*types.Alert) ([]*types.Alert, error) {
+	prepared := alerts
+
+	if len(c.options.globalLabels) > 0 || c.options.autoTimestamp || c.options.maxLabelsPerAlert > 0 {
+		now := c.options.clock.Now().UTC()
+		prepared = make([]*types.Alert, len(alerts))
+
+		for i, alert := range alerts {
+			clone := c.transformAlert(alert, now)
+
+			if limit := c.options.maxLabelsPerAlert; limit > 0 && len(clone.Metadata) > limit {
+				if !c.options.truncateExcessLabels {
+					return nil, fmt.Errorf("alert at index %d: too many labels (%d > %d)", i, len(clone.Metadata), limit)
+				}
+
+				var dropped []string
+
+				clone.Metadata, dropped = truncateLabels(clone.Metadata, limit)
+
+				c.options.requestLogger.Warnf("alert at index %d: dropped %d labels above the limit of %d: %s", i, len(dropped), limit, strings.Join(dropped, ", "))
+			}
+
+			prepared[i] = clone
+		}
+	}
+
+	if less := c.options.sortLess; less != nil {
+		prepared = slices.Clone(prepared)
+
+		slices.SortStableFunc(prepared, func(a, b *types.Alert) int {
+			switch {
+			case less(a, b):
+				return -1
+			case less(b, a):
+				return 1
+			default:
+				return 0
+			}
+		})
+	}
+
+	return prepared, nil
+}
+
+// transformAlert returns a clone of alert with the global labels and the
+// automatic timestamp applied.
+func (c *Client) transformAlert(alert *types.Alert, now time.Time) *types.Alert {
+	clone := cloneAlert(alert)
+
+	if len(c.options.globalLabels) > 0 {
+		clone.Metadata = mergeGlobalLabels(alert.Metadata, c.options.globalLabels)
+	}
+
+	if c.options.autoTimestamp && clone.Timestamp.IsZero() {
+		clone.Timestamp = now
+	}
+
+	return clone
+}
+
+// cloneAlert returns a shallow copy of alert. Fields that a transformation
+// replaces must be assigned fresh values on the copy rather than mutated.
+func cloneAlert(alert *types.Alert) *types.Alert {
+	clone := *alert
+	return &clone
+}
+
+// mergeGlobalLabels returns a new metadata map holding labels overlaid by
+// metadata, so that alert-local values win over the global ones.
+func mergeGlobalLabels(metadata map[string]any, labels map[string]string) map[string]any {
+	merged := make(map[string]any, len(metadata)+len(labels))
+
+	for key, value := range labels {
+		merged[key] = value
+	}
+
+	maps.Copy(merged, metadata)
+
+	return merged
+}
+
+// truncateLabels returns a new map holding the first limit labels of
+// metadata in key order, and the keys of the labels left out.
+func truncateLabels(metadata map[string]any, limit int) (map[string]any, []string) {
+	keys := slices.Sorted(maps.Keys(metadata))
+
+	kept := make(map[string]any, limit)
+	for _, key := range keys[:limit] {
+		kept[key] = metadata[key]
+	}
+
+	return kept, keys[limit:]
+}
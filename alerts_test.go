@@ -0,0 +1,154 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func mustPrepareAlerts(t *testing.T, c *Client, alerts []*types.Alert) []*types.Alert {
+	t.Helper()
+
+	prepared, err := c.prepareAlerts(alerts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return prepared
+}
+
+func TestPrepareAlerts_NoTransformations(t *testing.T) {
+	t.Parallel()
+
+	c := New("http://example.com")
+	alerts := []*types.Alert{{Header: "a"}}
+
+	prepared := mustPrepareAlerts(t, c, alerts)
+
+	if prepared[0] != alerts[0] {
+		t.Error("expected alerts to be passed through unchanged")
+	}
+}
+
+func TestPrepareAlerts_GlobalLabels(t *testing.T) {
+	t.Parallel()
+
+	c := New("http://example.com", WithGlobalLabels(map[string]string{"service": "checkout", "region": "eu"}))
+	original := &types.Alert{Header: "a", Metadata: map[string]any{"region": "us"}}
+
+	prepared := mustPrepareAlerts(t, c, []*types.Alert{original})
+
+	if prepared[0] == original {
+		t.Fatal("expected alert to be cloned")
+	}
+
+	if prepared[0].Metadata["service"] != "checkout" {
+		t.Errorf("expected service=checkout, got %v", prepared[0].Metadata["service"])
+	}
+
+	if prepared[0].Metadata["region"] != "us" {
+		t.Errorf("expected alert-local region=us to win, got %v", prepared[0].Metadata["region"])
+	}
+
+	if len(original.Metadata) != 1 {
+		t.Errorf("expected original metadata to be untouched, got %v", original.Metadata)
+	}
+}
+
+func TestPrepareAlerts_Sorting(t *testing.T) {
+	t.Parallel()
+
+	c := New("http://example.com", WithSortAlerts(func(a, b *types.Alert) bool {
+		if a.Header != b.Header {
+			return a.Header < b.Header
+		}
+		return a.Text < b.Text
+	}))
+
+	alerts := []*types.Alert{
+		{Header: "b", Text: "1"},
+		{Header: "a", Text: "2"},
+		{Header: "a", Text: "1"},
+	}
+
+	prepared := mustPrepareAlerts(t, c, alerts)
+
+	var got []string
+	for _, alert := range prepared {
+		got = append(got, alert.Header+alert.Text)
+	}
+
+	if strings.Join(got, ",") != "a1,a2,b1" {
+		t.Errorf("expected sorted order a1,a2,b1, got %v", got)
+	}
+
+	if alerts[0].Header != "b" || alerts[1].Text != "2" {
+		t.Error("expected caller's slice to keep its order")
+	}
+}
+
+func TestPrepareAlerts_AutoTimestamp(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	explicit := now.Add(-time.Hour)
+
+	c := New("http://example.com", WithAutoTimestamp(true), WithClock(newFakeClock(now)))
+	unset := &types.Alert{Header: "a"}
+	set := &types.Alert{Header: "b", Timestamp: explicit}
+
+	prepared := mustPrepareAlerts(t, c, []*types.Alert{unset, set})
+
+	if !prepared[0].Timestamp.Equal(now) {
+		t.Errorf("expected zero timestamp to be stamped with %v, got %v", now, prepared[0].Timestamp)
+	}
+
+	if !prepared[1].Timestamp.Equal(explicit) {
+		t.Errorf("expected explicit timestamp %v to be kept, got %v", explicit, prepared[1].Timestamp)
+	}
+
+	if !unset.Timestamp.IsZero() {
+		t.Errorf("expected original alert to be untouched, got %v", unset.Timestamp)
+	}
+}
+
+func TestPrepareAlerts_MaxLabels(t *testing.T) {
+	t.Parallel()
+
+	within := &types.Alert{Header: "a", Metadata: map[string]any{"a": 1}}
+	over := &types.Alert{Header: "b", Metadata: map[string]any{"c": 3, "a": 1, "b": 2}}
+
+	t.Run("rejects alerts over the limit", func(t *testing.T) {
+		t.Parallel()
+
+		c := New("http://example.com", WithMaxLabelsPerAlert(2), WithGlobalLabels(map[string]string{"env": "prod"}))
+
+		_, err := c.prepareAlerts([]*types.Alert{within, over})
+		if err == nil || err.Error() != "alert at index 1: too many labels (4 > 2)" {
+			t.Fatalf("expected too many labels error, got %v", err)
+		}
+	})
+
+	t.Run("truncates alerts over the limit", func(t *testing.T) {
+		t.Parallel()
+
+		logger := &recordingLogger{}
+		c := New("http://example.com", WithMaxLabelsPerAlert(2), WithTruncateExcessLabels(true), WithRequestLogger(logger))
+
+		prepared := mustPrepareAlerts(t, c, []*types.Alert{within, over})
+
+		if len(prepared[1].Metadata) != 2 || prepared[1].Metadata["a"] != 1 || prepared[1].Metadata["b"] != 2 {
+			t.Errorf("expected labels a and b to be kept, got %v", prepared[1].Metadata)
+		}
+
+		if len(over.Metadata) != 3 {
+			t.Errorf("expected caller's metadata to be untouched, got %v", over.Metadata)
+		}
+
+		if !strings.Contains(logger.String(), "warn: alert at index 1: dropped 1 labels above the limit of 2: c") {
+			t.Errorf("expected a warning about the dropped label, got:\n%s", logger.String())
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// maxErrorBodySize caps the response body kept in an [APIError].
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned when the API answers a request with a non-2xx
+// status. Besides the concise message of its Error method, it carries the
+// response body and headers, so that callers can extract them with
+// [errors.As] rather than parse the message.
+type APIError struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// URL is the request URL, with any credentials masked.
+	URL string
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Message is the server's error message: the JSON "error" field of the
+	// response body, or the field set with [WithErrorMessagePath], and
+	// otherwise the raw body, truncated.
+	Message string
+
+	// Body is the raw response body, truncated to its first 64 KiB.
+	Body []byte
+
+	// Header holds the response headers.
+	Header http.Header
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s failed with status code %d: %s", e.Method, e.URL, e.StatusCode, e.Message)
+}
+
+// newAPIError builds the [APIError] of the non-2xx response to a method
+// request.
+func (c *Client) newAPIError(method string, response *resty.Response) *APIError {
+	body := response.Body()
+	if len(body) > maxErrorBodySize {
+		body = body[:maxErrorBodySize]
+	}
+
+	return &APIError{
+		Method:     method,
+		URL:        sanitizeURL(response.Request.URL),
+		StatusCode: response.StatusCode(),
+		Message:    getBodyErrorMessage(response, c.options.errorMessagePath),
+		Body:       bytes.Clone(body),
+		Header:     response.Header().Clone(),
+	}
+}
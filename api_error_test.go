@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Parallel()
+
+	body := `{"error":"channel not found","details":{"channel":"C123"}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Request-Id", "req-42")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(body))
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(), &types.Alert{})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T: %v", err, err)
+	}
+
+	if apiErr.Method != http.MethodPost || apiErr.StatusCode != http.StatusBadRequest || apiErr.URL != server.URL+"/alerts" {
+		t.Errorf("unexpected request details: %+v", apiErr)
+	}
+
+	if apiErr.Message != "channel not found" || string(apiErr.Body) != body {
+		t.Errorf("unexpected message %q or body %q", apiErr.Message, apiErr.Body)
+	}
+
+	if got := apiErr.Header.Get("X-Request-Id"); got != "req-42" {
+		t.Errorf("expected the response headers, got X-Request-Id=%q", got)
+	}
+
+	if want := "POST " + server.URL + "/alerts failed with status code 400: channel not found"; err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err.Error())
+	}
+}
+
+func TestAPIError_BodyCapped(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(strings.Repeat("x", 2*maxErrorBodySize)))
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	var apiErr *APIError
+	if err := c.Send(context.Background(), &types.Alert{}); !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T: %v", err, err)
+	}
+
+	if len(apiErr.Body) != maxErrorBodySize {
+		t.Errorf("expected the body to be capped at %d bytes, got %d", maxErrorBodySize, len(apiErr.Body))
+	}
+
+	if !strings.Contains(apiErr.Message, "(truncated, 131072 bytes total)") {
+		t.Errorf("expected the message to stay truncated, got %d bytes", len(apiErr.Message))
+	}
+}
+
+func TestAPIError_Ping(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(0))
+
+	var apiErr *APIError
+	if err := c.Connect(context.Background()); !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError from the ping, got %T: %v", err, err)
+	}
+
+	if apiErr.Method != http.MethodGet || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected error details: %+v", apiErr)
+	}
+}
+
+func TestAPIError_RawBodyMessage(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("upstream unavailable"))
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	var apiErr *APIError
+	if err := c.Send(context.Background(), &types.Alert{}); !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T: %v", err, err)
+	}
+
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Message != "upstream unavailable" {
+		t.Errorf("expected the raw body as message, got %d %q", apiErr.StatusCode, apiErr.Message)
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// apiVersionHeader is the response header in which the server reports the
+// API version of the response, see [WithExpectedResponseVersion].
+const apiVersionHeader = "X-API-Version"
+
+// apiVersionPattern matches the API versions accepted by
+// [WithPreferredAPIVersion], such as "v2".
+var apiVersionPattern = regexp.MustCompile(`^v[1-9][0-9]*$`)
+
+// pingVersions is the part of the ping response body that advertises the API
+// versions supported by the server.
+type pingVersions struct {
+	SupportedVersions []string `json:"supported_versions"`
+}
+
+// APIVersion returns the API version negotiated by [Client.Connect], such as
+// "v2", or an empty string when sends use the unversioned alerts endpoints.
+// See [WithPreferredAPIVersion].
+func (c *Client) APIVersion() string {
+	if c == nil {
+		return ""
+	}
+
+	return c.apiVersion
+}
+
+// negotiateAPIVersion selects the API version to send alerts with from the
+// versions advertised in the ping body: the preferred version if the server
+// supports it, and otherwise the highest supported version below it. An
+// empty result means the unversioned endpoints are used.
+func (c *Client) negotiateAPIVersion(pingBody []byte) (string, error) {
+	preferred := c.options.preferredAPIVersion
+
+	var advertised pingVersions
+
+	// A body that is not JSON advertises no versions.
+	_ = json.Unmarshal(pingBody, &advertised)
+
+	fallback, fallbackNumber := "", 0
+	preferredNumber := apiVersionNumber(preferred)
+
+	for _, version := range advertised.SupportedVersions {
+		if version == preferred {
+			return preferred, nil
+		}
+
+		if n := apiVersionNumber(version); n > fallbackNumber && n < preferredNumber {
+			fallback, fallbackNumber = version, n
+		}
+	}
+
+	supported := strings.Join(advertised.SupportedVersions, ", ")
+	if supported == "" {
+		supported = "none advertised"
+	}
+
+	if c.options.strictVersioning {
+		return "", fmt.Errorf("API version %s is not supported by the server (supported: %s)", preferred, supported)
+	}
+
+	if fallback == "" {
+		c.options.requestLogger.Warnf("API version %s is not supported by the server (supported: %s), falling back to the unversioned endpoints", preferred, supported)
+	} else {
+		c.options.requestLogger.Warnf("API version %s is not supported by the server (supported: %s), falling back to %s", preferred, supported, fallback)
+	}
+
+	return fallback, nil
+}
+
+// versionedEndpoint prefixes endpoint with the negotiated API version, if
+// any, turning "alerts" into "v2/alerts".
+func (c *Client) versionedEndpoint(endpoint string) string {
+	if c.apiVersion == "" {
+		return endpoint
+	}
+
+	return path.Join(c.apiVersion, endpoint)
+}
+
+// apiVersionNumber returns the number of an API version such as "v2", or 0
+// if version is not a valid API version.
+func apiVersionNumber(version string) int {
+	if !apiVersionPattern.MatchString(version) {
+		return 0
+	}
+
+	n, err := strconv.Atoi(version[1:])
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
+
+// checkResponseVersion compares the API version reported by the server in a
+// response to the one set with [WithExpectedResponseVersion]. A mismatch is
+// logged as a warning, or returned as an error wrapping
+// [ErrResponseVersionMismatch] with [WithStrictVersioning].
+func (c *Client) checkResponseVersion(response *resty.Response) error {
+	expected := c.options.expectedResponseVersion
+	if expected == "" {
+		return nil
+	}
+
+	version := strings.TrimSpace(response.Header().Get(apiVersionHeader))
+	if version == "" || version == expected {
+		return nil
+	}
+
+	if c.options.strictVersioning {
+		return fmt.Errorf("%w: %s %s returned %s %q, expected %q", ErrResponseVersionMismatch, response.Request.Method, sanitizeURL(response.Request.URL), apiVersionHeader, version, expected)
+	}
+
+	c.options.requestLogger.Warnf("%s %s returned %s %q, expected %q", response.Request.Method, sanitizeURL(response.Request.URL), apiVersionHeader, version, expected)
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		preferred string
+		strict    bool
+		body      string
+		expected  string
+		wantErr   bool
+		wantWarn  bool
+	}{
+		{"preferred supported", "v2", false, `{"supported_versions":["v1","v2"]}`, "v2", false, false},
+		{"falls back to highest older version", "v3", false, `{"supported_versions":["v1","v2","v4"]}`, "v2", false, true},
+		{"falls back to unversioned", "v1", false, `{"supported_versions":["v2"]}`, "", false, true},
+		{"no versions advertised", "v2", false, `pong`, "", false, true},
+		{"strict rejects unsupported", "v3", true, `{"supported_versions":["v1","v2"]}`, "", true, false},
+		{"strict accepts supported", "v2", true, `{"supported_versions":["v2"]}`, "v2", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := &recordingLogger{}
+			c := New("http://example.com", WithPreferredAPIVersion(tt.preferred), WithStrictVersioning(tt.strict), WithRequestLogger(logger))
+
+			version, err := c.negotiateAPIVersion([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+
+			if version != tt.expected {
+				t.Errorf("expected version %q, got %q", tt.expected, version)
+			}
+
+			if warned := strings.Contains(logger.String(), "warn: API version"); warned != tt.wantWarn {
+				t.Errorf("expected warning=%v, got log:\n%s", tt.wantWarn, logger.String())
+			}
+		})
+	}
+}
+
+func TestClient_APIVersionRouting(t *testing.T) {
+	t.Parallel()
+
+	var sentTo string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			_, _ = w.Write([]byte(`{"supported_versions":["v1","v2"]}`))
+			return
+		}
+
+		sentTo = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("negotiated version", func(t *testing.T) {
+		c := New(server.URL, WithPreferredAPIVersion("v2"))
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+
+		if c.APIVersion() != "v2" {
+			t.Errorf("expected APIVersion=v2, got %q", c.APIVersion())
+		}
+
+		if err := c.Send(context.Background(), &types.Alert{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if sentTo != "/v2/alerts" {
+			t.Errorf("expected alerts to be sent to /v2/alerts, got %s", sentTo)
+		}
+	})
+
+	t.Run("strict versioning fails connect", func(t *testing.T) {
+		c := New(server.URL, WithPreferredAPIVersion("v3"), WithStrictVersioning(true))
+
+		err := c.Connect(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "API version v3 is not supported by the server (supported: v1, v2)") {
+			t.Fatalf("expected version negotiation error, got %v", err)
+		}
+	})
+
+	t.Run("no negotiation by default", func(t *testing.T) {
+		c := New(server.URL)
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+
+		if err := c.Send(context.Background(), &types.Alert{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if sentTo != "/alerts" {
+			t.Errorf("expected alerts to be sent to /alerts, got %s", sentTo)
+		}
+	})
+}
+
+func TestClient_ExpectedResponseVersion(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if version := r.URL.Query().Get("version"); version != "" {
+			w.Header().Set("X-API-Version", version)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name     string
+		version  string
+		strict   bool
+		wantErr  bool
+		wantWarn bool
+	}{
+		{"matching version", "2024-01", false, false, false},
+		{"missing header", "", true, false, false},
+		{"mismatch warns", "2025-06", false, false, true},
+		{"mismatch fails in strict mode", "2025-06", true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := &recordingLogger{}
+
+			c := New(server.URL,
+				WithExpectedResponseVersion("2024-01"),
+				WithStrictVersioning(tt.strict),
+				WithResolveQueryParam("version", tt.version),
+				WithRequestLogger(logger),
+			)
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			err := c.Resolve(context.Background(), &types.Alert{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+
+			if err != nil && !errors.Is(err, ErrResponseVersionMismatch) {
+				t.Errorf("expected ErrResponseVersionMismatch, got %v", err)
+			}
+
+			if warned := strings.Contains(logger.String(), `returned X-API-Version "2025-06", expected "2024-01"`); warned != tt.wantWarn {
+				t.Errorf("expected warning=%v, got log:\n%s", tt.wantWarn, logger.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const redactedValue = "[REDACTED]"
+
+// auditRecord is a single line written to the audit writer.
+type auditRecord struct {
+	Time           time.Time         `json:"time"`
+	Method         string            `json:"method"`
+	URL            string            `json:"url"`
+	Status         int               `json:"status,omitempty"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	RequestBody    string            `json:"request_body,omitempty"`
+	ResponseBody   string            `json:"response_body,omitempty"`
+	Error          string            `json:"error,omitempty"`
+}
+
+// auditLogger writes one JSON line per request to w, serialising writes so
+// that lines from concurrent requests never interleave.
+type auditLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (a *auditLogger) write(record *auditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, err = a.w.Write(append(line, '\n'))
+
+	return err
+}
+
+// audit writes the audit record of a completed request, if an audit writer
+// is configured. A failed write is logged but never fails the request.
+func (c *Client) audit(method, path string, body []byte, response *resty.Response, err error) {
+	if c.auditLog == nil {
+		return
+	}
+
+	record := &auditRecord{
+		Time:        c.options.clock.Now().UTC(),
+		Method:      method,
+		URL:         path,
+		RequestBody: string(body),
+	}
+
+	if response != nil {
+		if raw := response.Request.RawRequest; raw != nil {
+			record.URL = sanitizeURL(raw.URL.String())
+			record.RequestHeaders = redactHeaders(raw.Header)
+		}
+
+		if response.RawResponse != nil {
+			record.Status = response.StatusCode()
+			record.ResponseBody = string(response.Body())
+		}
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	if writeErr := c.auditLog.write(record); writeErr != nil {
+		c.options.requestLogger.Errorf("failed to write audit record for %s %s: %v", method, record.URL, writeErr)
+	}
+}
+
+// redactHeaders flattens h, replacing the values of credential headers.
+func redactHeaders(h http.Header) map[string]string {
+	headers := flattenHeaders(h)
+
+	for key := range headers {
+		switch http.CanonicalHeaderKey(key) {
+		case "Authorization", "Proxy-Authorization", "Cookie":
+			headers[key] = redactedValue
+		}
+	}
+
+	return headers
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func readAuditRecords(t *testing.T, buf *bytes.Buffer) []auditRecord {
+	t.Helper()
+
+	var records []auditRecord
+
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var record auditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestSend_AuditWriter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"accepted":1}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	var buf bytes.Buffer
+
+	c := New(server.URL, WithAuditWriter(&buf), WithAuthToken("secret-token"), WithClock(newFakeClock(now)))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Error("expected the auth token to be redacted")
+	}
+
+	records := readAuditRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 audit records, got %d", len(records))
+	}
+
+	if records[0].Method != http.MethodGet || records[0].URL != server.URL+"/ping" {
+		t.Errorf("expected ping record, got %+v", records[0])
+	}
+
+	send := records[1]
+
+	if !send.Time.Equal(now) || send.Method != http.MethodPost || send.URL != server.URL+"/alerts" || send.Status != http.StatusAccepted {
+		t.Errorf("unexpected send record %+v", send)
+	}
+
+	if !strings.Contains(send.RequestBody, `"header":"test"`) || send.ResponseBody != `{"accepted":1}` {
+		t.Errorf("expected request and response bodies, got %q and %q", send.RequestBody, send.ResponseBody)
+	}
+
+	if send.RequestHeaders["Authorization"] != "[REDACTED]" {
+		t.Errorf("expected redacted Authorization header, got %q", send.RequestHeaders["Authorization"])
+	}
+}
+
+func TestSend_AuditWriter_NetworkError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var buf bytes.Buffer
+
+	c := New(server.URL, WithAuditWriter(&buf), WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	server.Close()
+	buf.Reset()
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err == nil {
+		t.Fatal("expected send to fail")
+	}
+
+	records := readAuditRecords(t, &buf)
+	if len(records) != 1 || records[0].Status != 0 || records[0].Error == "" {
+		t.Errorf("expected one record with an error and no status, got %+v", records)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestSend_AuditWriter_WriteFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithAuditWriter(failingWriter{}))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Errorf("expected audit write failures not to fail the send, got %v", err)
+	}
+}
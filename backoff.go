@@ -0,0 +1,86 @@
+package client
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// retryWait is the resty RetryAfter callback. It honours a Retry-After
+// header up to the cap set with [WithMaxRetryAfter], and otherwise waits
+// for a jittered exponential backoff between the configured retry wait
+// times. Computing the backoff here rather than leaving it to resty keeps it
+// bounded by retryMaxWaitTime even when the Retry-After cap is higher.
+//
+// The wait is a duration, which resty sleeps on the monotonic clock, so a
+// wall-clock jump during the wait neither extends nor shortens it.
+func (c *Client) retryWait(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if planned, ok := c.plannedWaits.LoadAndDelete(resp.Request); ok {
+		return planned.(time.Duration), nil
+	}
+
+	return c.nextRetryWait(resp)
+}
+
+// nextRetryWait computes the wait before the next retry of resp, see
+// [Client.retryWait].
+func (c *Client) nextRetryWait(resp *resty.Response) (time.Duration, error) {
+	wait, err := parseRetryAfterHeader(resp, c.options.clock)
+	if err != nil {
+		return 0, err
+	}
+
+	if wait > 0 {
+		return min(wait, c.options.retryAfterCap()), nil
+	}
+
+	return jitterBackoff(c.options.randSource, c.options.retryWaitTime, c.options.retryMaxWaitTime, resp.Request.Attempt-1), nil
+}
+
+// retryFitsDeadline reports whether the request behind r has enough time
+// left before its context deadline to wait for the next retry. When it has,
+// the wait is planned so that [Client.retryWait] uses the same value; when
+// it has not, retrying would only sleep until the deadline, so the request
+// stops with its last result instead.
+func (c *Client) retryFitsDeadline(r *resty.Response) bool {
+	if r == nil || r.Request == nil {
+		return true
+	}
+
+	deadline, ok := r.Request.Context().Deadline()
+	if !ok {
+		return true
+	}
+
+	wait, err := c.nextRetryWait(r)
+	if err != nil {
+		return true
+	}
+
+	wait = max(wait, c.options.retryWaitTime)
+
+	if remaining := time.Until(deadline); remaining <= wait {
+		c.options.requestLogger.Debugf("not retrying %s %s: %v left before the deadline, less than the %v retry wait",
+			r.Request.Method, sanitizeURL(r.Request.URL), remaining.Round(time.Millisecond), wait)
+
+		return false
+	}
+
+	c.plannedWaits.Store(r.Request, wait)
+
+	return true
+}
+
+// jitterBackoff returns a capped exponential backoff with jitter for the
+// given zero-based retry attempt: a random duration drawn from src in the
+// upper half of min·2^attempt, capped at maxWait, and never below min.
+func jitterBackoff(src rand.Source, minWait, maxWait time.Duration, attempt int) time.Duration {
+	ceiling := time.Duration(math.Min(float64(maxWait), float64(minWait)*math.Exp2(float64(max(attempt, 0)))))
+
+	half := max(ceiling/2, 1)
+	wait := half + time.Duration(rand.New(src).Int64N(int64(half))) //nolint:gosec // jitter does not need a secure source
+
+	return max(wait, minWait)
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
+)
+
+func TestJitterBackoff(t *testing.T) {
+	t.Parallel()
+
+	minWait := 100 * time.Millisecond
+	maxWait := time.Second
+
+	tests := []struct {
+		attempt int
+		low     time.Duration
+		high    time.Duration
+	}{
+		{0, 100 * time.Millisecond, 100 * time.Millisecond},
+		{1, 100 * time.Millisecond, 200 * time.Millisecond},
+		{2, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 100 {
+			if wait := jitterBackoff(globalRandSource{}, minWait, maxWait, tt.attempt); wait < tt.low || wait > tt.high {
+				t.Fatalf("attempt %d: expected wait in [%v, %v], got %v", tt.attempt, tt.low, tt.high, wait)
+			}
+		}
+	}
+}
+
+func TestSend_MaxRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "86400")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL,
+		WithRetryWaitTime(100*time.Millisecond),
+		WithRetryMaxWaitTime(100*time.Millisecond),
+		WithMaxRetryAfter(300*time.Millisecond),
+	)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	start := time.Now()
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The day-long Retry-After is capped above the retry max wait time
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("expected a wait of about 300ms, waited %v", elapsed)
+	}
+}
+
+func TestSend_NoRetryWaitPastDeadline(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(3), WithRetryWaitTime(500*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Send(ctx, &types.Alert{})
+	elapsed := time.Since(start)
+
+	if err == nil || !strings.Contains(err.Error(), "status code 503") {
+		t.Fatalf("expected the last 503 error, got %v", err)
+	}
+
+	if elapsed > 150*time.Millisecond {
+		t.Errorf("expected no backoff sleep past the deadline, took %v", elapsed)
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestSend_RetryWaitWithinDeadline(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(3), WithRetryWaitTime(100*time.Millisecond), WithRetryMaxWaitTime(100*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.Send(ctx, &types.Alert{}); err != nil {
+		t.Fatalf("expected the retry to succeed within the deadline, got %v", err)
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestRetryWait_WallClockJump(t *testing.T) {
+	t.Parallel()
+
+	serverNow := time.Now().UTC().Truncate(time.Second)
+	retryAt := serverNow.Add(5 * time.Second).Format(http.TimeFormat)
+
+	respond := func(t *testing.T, ctx context.Context, date bool) *resty.Response {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", retryAt)
+
+			if date {
+				w.Header().Set("Date", serverNow.Format(http.TimeFormat))
+			} else {
+				w.Header()["Date"] = nil
+			}
+
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(server.Close)
+
+		resp, err := resty.New().R().SetContext(ctx).Get(server.URL)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+
+		return resp
+	}
+
+	t.Run("http-date measured from the server's Date header", func(t *testing.T) {
+		t.Parallel()
+
+		// The local clock is three hours behind the server.
+		clock := newFakeClock(serverNow.Add(-3 * time.Hour))
+		c := New("http://example.invalid", WithClock(clock), WithMaxRetryAfter(time.Minute))
+
+		wait, err := c.retryWait(nil, respond(t, context.Background(), true))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if wait != 5*time.Second {
+			t.Errorf("expected a 5s wait whatever the local clock, got %v", wait)
+		}
+	})
+
+	t.Run("backward jump after the wait is planned", func(t *testing.T) {
+		t.Parallel()
+
+		clock := newFakeClock(serverNow)
+		c := New("http://example.invalid", WithClock(clock), WithMaxRetryAfter(time.Minute))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		resp := respond(t, ctx, false)
+
+		if !c.retryFitsDeadline(resp) {
+			t.Fatal("expected the retry to fit in the deadline")
+		}
+
+		// NTP moves the wall clock back while the request waits to retry.
+		clock.Advance(-time.Hour)
+
+		wait, err := c.retryWait(nil, resp)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if wait != 5*time.Second {
+			t.Errorf("expected the planned 5s wait to be kept, got %v", wait)
+		}
+	})
+}
+
+func TestRetryWait_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	serverNow := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"seconds", "120", 120 * time.Second},
+		{"http-date", serverNow.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{"absent header uses the backoff", "", 100 * time.Millisecond},
+		{"unparseable header uses the backoff", "soon", 100 * time.Millisecond},
+		{"capped", "999999", 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+
+				w.Header().Set("Date", serverNow.Format(http.TimeFormat))
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+			t.Cleanup(server.Close)
+
+			resp, err := resty.New().R().Get(server.URL)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+
+			c := New(server.URL, WithRetryWaitTime(100*time.Millisecond), WithMaxRetryAfter(5*time.Minute))
+
+			wait, err := c.retryWait(nil, resp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if wait != tt.expected {
+				t.Errorf("expected a wait of %v, got %v", tt.expected, wait)
+			}
+		})
+	}
+}
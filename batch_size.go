@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/slackmgr/types"
+)
+
+// batchEnvelopeSize is the size of the request envelope around the alerts,
+// i.e. len(`{"alerts":[]}`).
+const batchEnvelopeSize = len(`{"alerts":[]}`)
+
+// SendBatchBySize posts alerts in as many requests as needed to keep each
+// request body within maxBytes, for servers that limit the body size rather
+// than the number of alerts. Alerts are packed greedily in order, and the
+// envelope around them is included in the size.
+//
+// Sizes are estimated with [EstimateSendSize] rather than measured, so the
+// alerts are only marshaled once, when sent. The estimate never falls below
+// the compact JSON encoding, after [WithGlobalLabels] and [WithAutoTimestamp]
+// are applied. [WithFieldEncryption] and [WithIndentedJSON] make the body
+// larger than estimated, so leave headroom when using them.
+//
+// An alert that does not fit in a batch on its own fails the call with
+// [ErrAlertTooLarge] before anything is sent. Otherwise batches are sent one
+// after another with [Client.Send], stopping at the first failure; batches
+// sent before it have been delivered. A batch whose alerts are all dropped
+// by [WithMinSeverity] is skipped and the call goes on with the next one; it
+// returns [ErrNoAlertsAfterFilter] only when every batch was dropped. The
+// callback set with [WithBatchCallback] is called after each batch,
+// including the failed and dropped ones.
+func (c *Client) SendBatchBySize(ctx context.Context, maxBytes int, alerts ...*types.Alert) error {
+	if c == nil {
+		return errors.New("alert client is nil")
+	}
+
+	if envelopeSize := c.envelopeSize(); maxBytes <= envelopeSize {
+		return fmt.Errorf("maxBytes must be greater than %d", envelopeSize)
+	}
+
+	if err := validateAlerts(alerts); err != nil {
+		return err
+	}
+
+	batches, err := c.splitBySize(maxBytes, alerts)
+	if err != nil {
+		return err
+	}
+
+	var delivered bool
+
+	for i, batch := range batches {
+		err := c.Send(ctx, batch...)
+
+		if c.options.batchCallback != nil {
+			c.options.batchCallback(ctx, i, len(batch), err)
+		}
+
+		if errors.Is(err, ErrNoAlertsAfterFilter) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("batch %d of %d: %w", i+1, len(batches), err)
+		}
+
+		delivered = true
+	}
+
+	if !delivered {
+		return ErrNoAlertsAfterFilter
+	}
+
+	return nil
+}
+
+// splitBySize packs alerts into consecutive batches whose encoded envelope
+// stays within maxBytes.
+func (c *Client) splitBySize(maxBytes int, alerts []*types.Alert) ([][]*types.Alert, error) {
+	var (
+		batches [][]*types.Alert
+		batch   []*types.Alert
+		size    int
+	)
+
+	now := c.options.clock.Now().UTC()
+	envelopeSize := c.envelopeSize()
+
+	for i, alert := range alerts {
+		// Dropping labels above WithMaxLabelsPerAlert only makes the alert
+		// smaller, so it is left out of the estimate.
+		alertSize := estimateJSONSize(reflect.ValueOf(c.transformAlert(alert, now)))
+
+		if envelopeSize+alertSize > maxBytes {
+			return nil, fmt.Errorf("%w: alert at index %d needs %d bytes, the limit is %d bytes", ErrAlertTooLarge, i, envelopeSize+alertSize, maxBytes)
+		}
+
+		// Every alert after the first one in a batch needs a separating comma.
+		if len(batch) > 0 && envelopeSize+size+1+alertSize > maxBytes {
+			batches = append(batches, batch)
+			batch, size = nil, 0
+		}
+
+		if len(batch) > 0 {
+			size++
+		}
+
+		batch = append(batch, alert)
+		size += alertSize
+	}
+
+	return append(batches, batch), nil
+}
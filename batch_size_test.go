@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSendBatchBySize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("packs alerts under the limit", func(t *testing.T) {
+		t.Parallel()
+
+		c, recorder := newRecordingClient(t, nil)
+
+		alerts := []*types.Alert{
+			{Text: strings.Repeat("a", 700)},
+			{Text: strings.Repeat("b", 10)},
+			{Text: strings.Repeat("c", 900)},
+			{Text: strings.Repeat("d", 10)},
+			{Text: strings.Repeat("e", 10)},
+		}
+
+		const maxBytes = 2000
+
+		if err := c.SendBatchBySize(context.Background(), maxBytes, alerts...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		batches := recorder.all()
+		if len(batches) < 2 {
+			t.Fatalf("expected the alerts to be split, got %d batch(es)", len(batches))
+		}
+
+		var texts []string
+
+		for i, batch := range batches {
+			if len(batch.body) > maxBytes {
+				t.Errorf("batch %d is %d bytes, above the %d byte limit", i, len(batch.body), maxBytes)
+			}
+
+			texts = append(texts, batch.texts()...)
+		}
+
+		if len(texts) != len(alerts) {
+			t.Fatalf("expected %d alerts in total, got %d", len(alerts), len(texts))
+		}
+
+		for i, alert := range alerts {
+			if texts[i] != alert.Text {
+				t.Errorf("expected alert %d to keep its order", i)
+			}
+		}
+	})
+
+	t.Run("oversized alert fails before sending", func(t *testing.T) {
+		t.Parallel()
+
+		c, recorder := newRecordingClient(t, nil)
+
+		err := c.SendBatchBySize(context.Background(), 1000, &types.Alert{Text: "ok"}, &types.Alert{Text: strings.Repeat("x", 1000)})
+		if !errors.Is(err, ErrAlertTooLarge) {
+			t.Fatalf("expected ErrAlertTooLarge, got %v", err)
+		}
+
+		if !strings.Contains(err.Error(), "alert at index 1") {
+			t.Errorf("expected the error to name the alert, got %v", err)
+		}
+
+		if len(recorder.all()) != 0 {
+			t.Error("expected nothing to be sent")
+		}
+	})
+
+	t.Run("limit below the envelope size", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newRecordingClient(t, nil)
+
+		err := c.SendBatchBySize(context.Background(), 10, &types.Alert{})
+		if err == nil || !strings.Contains(err.Error(), "maxBytes must be greater than") {
+			t.Fatalf("expected maxBytes error, got %v", err)
+		}
+	})
+}
+
+func TestSendBatchBySize_MinSeverity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("batch dropped by the filter is skipped", func(t *testing.T) {
+		t.Parallel()
+
+		c, recorder := newRecordingClient(t, nil, WithMinSeverity("warning"))
+
+		// Each alert fills a batch of its own, so the first batch only holds
+		// the info alert
+		alerts := []*types.Alert{
+			{Text: strings.Repeat("a", 600), Severity: types.AlertInfo},
+			{Text: strings.Repeat("b", 600), Severity: types.AlertWarning},
+			{Text: strings.Repeat("c", 600), Severity: types.AlertPanic},
+		}
+
+		if err := c.SendBatchBySize(context.Background(), 2000, alerts...); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var texts []string
+		for _, r := range recorder.all() {
+			texts = append(texts, r.texts()...)
+		}
+
+		if len(texts) != 2 || texts[0] != alerts[1].Text || texts[1] != alerts[2].Text {
+			t.Errorf("expected the batches after the dropped one to be sent, got %d alerts", len(texts))
+		}
+	})
+
+	t.Run("every batch dropped", func(t *testing.T) {
+		t.Parallel()
+
+		c, recorder := newRecordingClient(t, nil, WithMinSeverity("warning"))
+
+		err := c.SendBatchBySize(context.Background(), 2000,
+			&types.Alert{Text: strings.Repeat("a", 600), Severity: types.AlertInfo},
+			&types.Alert{Text: strings.Repeat("b", 600), Severity: types.AlertInfo},
+		)
+		if !errors.Is(err, ErrNoAlertsAfterFilter) {
+			t.Errorf("expected ErrNoAlertsAfterFilter, got %v", err)
+		}
+
+		if len(recorder.all()) != 0 {
+			t.Error("expected nothing to be sent")
+		}
+	})
+}
+
+func TestSendBatchBySize_BatchCallback(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && requests.Add(1) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	type call struct {
+		index, size int
+		err         error
+	}
+
+	var calls []call
+
+	c := New(server.URL, WithRetryCount(0), WithBatchCallback(func(_ context.Context, batchIndex, batchSize int, err error) {
+		calls = append(calls, call{batchIndex, batchSize, err})
+	}))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	alerts := []*types.Alert{
+		{Text: strings.Repeat("a", 600)},
+		{Text: strings.Repeat("b", 600)},
+		{Text: strings.Repeat("c", 600)},
+		{Text: strings.Repeat("d", 600)},
+	}
+
+	err := c.SendBatchBySize(context.Background(), 1500, alerts...)
+	if err == nil || !strings.HasPrefix(err.Error(), "batch 2 of 4: ") {
+		t.Fatalf("expected batch 2 to fail, got %v", err)
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 callbacks, got %d", len(calls))
+	}
+
+	if calls[0].index != 0 || calls[0].size != 1 || calls[0].err != nil {
+		t.Errorf("expected a successful first batch, got %+v", calls[0])
+	}
+
+	if calls[1].index != 1 || calls[1].size != 1 || calls[1].err == nil {
+		t.Errorf("expected a failed second batch, got %+v", calls[1])
+	}
+}
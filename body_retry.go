@@ -0,0 +1,37 @@
+package client
+
+import (
+	"bytes"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// retryableBodyMatch returns the first substring configured with
+// [WithRetryOnBodyContains] found in the body of a successful response.
+func (c *Client) retryableBodyMatch(r *resty.Response) (string, bool) {
+	if r == nil || !r.IsSuccess() {
+		return "", false
+	}
+
+	body := r.Body()
+
+	for _, substring := range c.options.retryBodySubstrings {
+		if bytes.Contains(body, []byte(substring)) {
+			return substring, true
+		}
+	}
+
+	return "", false
+}
+
+// bodyRetryCondition is a resty retry condition that retries successful
+// responses whose body matches [WithRetryOnBodyContains].
+func (c *Client) bodyRetryCondition(r *resty.Response, err error) bool {
+	if err != nil {
+		return false
+	}
+
+	_, ok := c.retryableBodyMatch(r)
+
+	return ok
+}
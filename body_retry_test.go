@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSend_RetryOnBodyContains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		overloaded       int32
+		expectedAttempts int32
+		expectedError    string
+	}{
+		{"no match", 0, 1, ""},
+		{"recovers after retry", 2, 3, ""},
+		{"retries exhausted", 10, 3, `returned status code 200 with retryable body content "queue full" after all retries`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/alerts" {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+
+				if attempts.Add(1) <= tt.overloaded {
+					_, _ = w.Write([]byte(`"queue full, retry"`))
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"status":"ok"}`))
+			}))
+			defer server.Close()
+
+			c := New(server.URL,
+				WithRetryCount(2),
+				WithRetryWaitTime(100*time.Millisecond),
+				WithRetryMaxWaitTime(100*time.Millisecond),
+				WithRetryOnBodyContains("queue full", ""),
+			)
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			err := c.Send(context.Background(), &types.Alert{Header: "test"})
+
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if tt.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedError)) {
+				t.Errorf("expected error containing %q, got %v", tt.expectedError, err)
+			}
+
+			if n := attempts.Load(); n != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, n)
+			}
+		})
+	}
+}
+
+func TestSend_RetryOnBodyContains_IgnoresErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`queue full`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetryOnBodyContains("queue full"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err == nil {
+		t.Fatal("expected send to fail")
+	}
+
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("expected a 400 not to be retried, got %d attempts", n)
+	}
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// normalizeFingerprint lowercases a hex fingerprint and strips the colon
+// and space separators commonly used when printing certificate fingerprints.
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", " ", "").Replace(fingerprint))
+}
+
+// isValidFingerprint reports whether fingerprint is a normalized hex SHA-256
+// digest.
+func isValidFingerprint(fingerprint string) bool {
+	decoded, err := hex.DecodeString(fingerprint)
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// pinnedTLSConfig returns a copy of config (which may be nil) that rejects
+// connections unless a certificate presented by the server has one of the
+// pinned SHA-256 fingerprints. Any verification already configured still
+// runs first.
+func pinnedTLSConfig(config *tls.Config, pins []string) *tls.Config {
+	if config == nil {
+		config = &tls.Config{} //nolint:gosec // MinVersion defaults to TLS 1.2 for clients
+	} else {
+		config = config.Clone()
+	}
+
+	verify := config.VerifyConnection
+
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		if verify != nil {
+			if err := verify(state); err != nil {
+				return err
+			}
+		}
+
+		for _, cert := range state.PeerCertificates {
+			sum := sha256.Sum256(cert.Raw)
+			if slices.Contains(pins, hex.EncodeToString(sum[:])) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w for %s", ErrCertificatePinMismatch, state.ServerName)
+	}
+
+	return config
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNormalizeFingerprint(t *testing.T) {
+	t.Parallel()
+
+	if got := normalizeFingerprint("AB:cd 12"); got != "abcd12" {
+		t.Errorf("expected abcd12, got %s", got)
+	}
+}
+
+// newPinTestServer starts a TLS test server counting the requests it serves
+// and returns it with the fingerprint of its certificate.
+func newPinTestServer(t *testing.T) (*httptest.Server, string, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+
+	return server, hex.EncodeToString(sum[:]), &requests
+}
+
+func TestConnect_CertificatePin(t *testing.T) {
+	t.Parallel()
+
+	server, fingerprint, _ := newPinTestServer(t)
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	// Print-style fingerprints with colons are accepted
+	var octets []string
+	for i := 0; i < len(fingerprint); i += 2 {
+		octets = append(octets, strings.ToUpper(fingerprint[i:i+2]))
+	}
+
+	c := New(server.URL, WithTLSConfig(tlsConfig), WithCertificatePin(strings.Repeat("ab", 32), strings.Join(octets, ":")))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+}
+
+func TestConnect_CertificatePinMismatch(t *testing.T) {
+	t.Parallel()
+
+	server, _, requests := newPinTestServer(t)
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	c := New(server.URL, WithTLSConfig(tlsConfig), WithCertificatePin(strings.Repeat("ab", 32)))
+
+	err := c.Connect(context.Background())
+	if !errors.Is(err, ErrCertificatePinMismatch) {
+		t.Fatalf("expected ErrCertificatePinMismatch, got %v", err)
+	}
+
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no request to reach the server, got %d", n)
+	}
+}
@@ -5,12 +5,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"mime"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/slackmgr/types"
@@ -20,42 +26,103 @@ import (
 // Use [New] to create a Client, then call [Client.Connect] to establish
 // the connection. Call [Client.Close] when finished to release resources.
 type Client struct {
-	baseURL    string
-	client     *resty.Client
-	options    *Options
-	once       sync.Once
-	connectErr error
-	transport  *http.Transport
+	baseURL       string
+	client        *resty.Client
+	options       *Options
+	once          sync.Once
+	connectErr    error
+	transport     *http.Transport
+	openConns     atomic.Int64
+	inUseConns    atomic.Int64
+	auditLog      *auditLogger
+	inFlight      inFlightRequests
+	encrypter     *fieldEncrypter
+	apiVersion    string
+	throttle      *adaptiveThrottle
+	inFlightBytes *byteLimiter
+	envelopeMeta  map[string]string
+	connected     atomic.Bool
+	lastTTFB      atomic.Int64 // time.Duration, see LastTTFB
+	diff          diffState
+	plannedWaits  sync.Map // *resty.Request -> time.Duration, see retryFitsDeadline
 }
 
-type alertsList struct {
-	Alerts []*types.Alert `json:"alerts"`
+const (
+	// maxErrorMessageLength caps raw response bodies quoted in error messages.
+	maxErrorMessageLength = 1024
+
+	// maxErrorLineLength caps the line quoted from an HTML error page.
+	maxErrorLineLength = 200
+
+	// channelHeader overrides the target Slack channel of a request.
+	channelHeader = "X-Slack-Channel"
+)
+
+// alertGroup is a set of alerts destined for the same API endpoint.
+type alertGroup struct {
+	endpoint   string
+	severities []string
+	alerts     []*types.Alert
+}
+
+// callOptions holds request settings that apply to a single call only, on
+// top of the client-wide configuration.
+type callOptions struct {
+	queryParams map[string]string
+	headers     map[string]string
+
+	// onSuccess, when set, receives every successful response.
+	onSuccess func(response *resty.Response)
 }
 
-// apiErrorResponse represents the standard error response from the API.
-type apiErrorResponse struct {
-	Error string `json:"error"`
+// withHeader returns a copy of call (which may be nil) with an added header.
+func (call *callOptions) withHeader(key, value string) *callOptions {
+	result := &callOptions{headers: map[string]string{key: value}}
+
+	if call != nil {
+		result.queryParams = call.queryParams
+		result.onSuccess = call.onSuccess
+		maps.Copy(result.headers, call.headers)
+	}
+
+	return result
+}
+
+type alertsList struct {
+	Alerts []*types.Alert    `json:"alerts"`
+	Meta   map[string]string `json:"meta,omitempty"`
 }
 
 // ResponseMetadata contains metadata from the HTTP response returned by [Client.SendWithResponse].
 type ResponseMetadata struct {
-	Duration   time.Duration
-	StatusCode int
-	Headers    map[string]string
+	Duration        time.Duration
+	TimeToFirstByte time.Duration
+	StatusCode      int
+	Headers         map[string]string
 }
 
 // New creates a new [Client] configured with the given base URL and options.
 // Call [Client.Connect] before sending alerts.
 func New(baseURL string, opts ...Option) *Client {
-	options := newClientOptions()
+	return &Client{
+		baseURL: baseURL,
+		options: newClientOptions().Apply(opts...),
+	}
+}
 
-	for _, o := range opts {
-		o(options)
+// NewFromOptions creates a new [Client] configured with the given base URL
+// and a copy of opts, typically built with [DefaultOptions] and
+// [Options.Apply]. Later changes to opts do not affect the client, so one
+// Options value can serve as a preset for many clients. A nil opts uses the
+// defaults. Call [Client.Connect] before sending alerts.
+func NewFromOptions(baseURL string, opts *Options) *Client {
+	if opts == nil {
+		return New(baseURL)
 	}
 
 	return &Client{
 		baseURL: baseURL,
-		options: options,
+		options: opts.clone(),
 	}
 }
 
@@ -74,28 +141,93 @@ func (c *Client) Connect(ctx context.Context) error {
 			return
 		}
 
-		// Configure transport with connection pool settings
-		c.transport = &http.Transport{
-			MaxIdleConns:      c.options.maxIdleConns,
-			MaxConnsPerHost:   c.options.maxConnsPerHost,
-			IdleConnTimeout:   c.options.idleConnTimeout,
-			DisableKeepAlives: c.options.disableKeepAlive,
-			TLSClientConfig:   c.options.tlsConfig,
+		if len(c.options.encryptedFields) > 0 {
+			encrypter, err := newFieldEncrypter(c.options.encryptionKey, c.options.encryptedFields)
+			if err != nil {
+				c.connectErr = fmt.Errorf("invalid field encryption: %w", err)
+				return
+			}
+
+			c.encrypter = encrypter
+		}
+
+		if c.options.envelopeMetadata != nil {
+			c.envelopeMeta = envelopeMetadata(c.options.envelopeMetadata)
+		}
+
+		if c.options.maxInFlightBytes > 0 {
+			c.inFlightBytes = newByteLimiter(c.options.maxInFlightBytes)
+		}
+
+		if c.options.throttleBaseDelay > 0 {
+			c.throttle = newAdaptiveThrottle(c.options.throttleBaseDelay, c.options.throttleMaxDelay)
+		}
+
+		if c.options.auditWriter != nil {
+			c.auditLog = &auditLogger{w: c.options.auditWriter}
+		}
+
+		if c.options.sharedTransport != nil {
+			c.transport = c.options.sharedTransport
+		} else {
+			c.transport = c.newTransport()
 		}
 
 		c.client = resty.New().
 			SetBaseURL(c.baseURL).
 			SetTimeout(c.options.timeout).
 			SetTransport(c.transport).
-			SetRedirectPolicy(resty.FlexibleRedirectPolicy(c.options.maxRedirects)).
+			SetRedirectPolicy(c.redirectPolicies()...).
 			SetRetryCount(c.options.retryCount).
 			SetRetryWaitTime(c.options.retryWaitTime).
-			SetRetryMaxWaitTime(c.options.retryMaxWaitTime).
-			AddRetryCondition(c.options.retryPolicy).
-			SetRetryAfter(parseRetryAfterHeader).
+			SetRetryMaxWaitTime(max(c.options.retryMaxWaitTime, c.options.retryAfterCap())).
+			AddRetryCondition(c.retryCondition).
+			SetRetryAfter(c.retryWait).
 			SetLogger(c.options.requestLogger).
 			SetHeader("User-Agent", c.options.userAgent)
 
+		if c.options.observer != nil {
+			c.client.AddRetryHook(c.observeRetry)
+		}
+
+		if c.options.debug {
+			enableDebugLogging(c.client)
+		}
+
+		if isInsecureTLS(c.transport) {
+			c.options.requestLogger.Warnf("TLS certificate verification is disabled; do not use this configuration in production")
+		}
+
+		if c.options.rateLimit > 0 || c.options.streamingEncode {
+			var limiter *hostRateLimiter
+
+			if c.options.rateLimit > 0 {
+				limiter = newHostRateLimiter(c.options.rateLimit, c.options.rateLimitBurst)
+			}
+
+			c.client.SetPreRequestHook(func(_ *resty.Client, req *http.Request) error {
+				if limiter != nil {
+					if err := limiter.wait(req.Context(), req.URL.Host); err != nil {
+						return err
+					}
+				}
+
+				attachStreamingBody(req)
+
+				return nil
+			})
+		}
+
+		if c.options.rateLimitHeadersFn != nil {
+			c.client.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+				if remaining, resetAt, ok := parseRateLimitHeaders(resp.Header(), c.options.clock.Now()); ok {
+					c.options.rateLimitHeadersFn(resp.Request.Context(), remaining, resetAt)
+				}
+
+				return nil
+			})
+		}
+
 		for key, value := range c.options.requestHeaders {
 			c.client.SetHeader(key, value)
 		}
@@ -107,15 +239,110 @@ func (c *Client) Connect(ctx context.Context) error {
 			c.client.SetAuthToken(c.options.authToken)
 		}
 
-		if err := c.ping(ctx); err != nil {
+		if err := c.waitConnectJitter(ctx); err != nil {
+			c.connectErr = fmt.Errorf("connect jitter wait aborted: %w", err)
+			return
+		}
+
+		pingBody, err := c.connectPing(ctx)
+		if err != nil && c.options.connectFallback.path != "" {
+			pingBody, err = nil, c.checkConnectFallback(ctx, err)
+		}
+
+		if err != nil {
 			c.connectErr = fmt.Errorf("failed to ping alerts API: %w", err)
 			return
 		}
+
+		if c.options.preferredAPIVersion != "" {
+			if c.apiVersion, err = c.negotiateAPIVersion(pingBody); err != nil {
+				c.connectErr = fmt.Errorf("API version negotiation failed: %w", err)
+				return
+			}
+		}
+
+		if err := c.runConnectProbes(ctx); err != nil {
+			c.connectErr = fmt.Errorf("connect probe failed: %w", err)
+			return
+		}
+
+		c.connected.Store(true)
 	})
 
 	return c.connectErr
 }
 
+// newTransport builds the client's own transport from the connection pool,
+// TLS and dialer options.
+func (c *Client) newTransport() *http.Transport {
+	tlsConfig := c.options.tlsConfig
+	if len(c.options.tlsCipherSuites) > 0 {
+		tlsConfig = cipherSuitesTLSConfig(tlsConfig, c.options.tlsCipherSuites)
+	}
+
+	if c.options.clientCertReloader != nil {
+		tlsConfig = reloadingTLSConfig(tlsConfig, c.options.clientCertReloader)
+	}
+
+	if len(c.options.certificatePins) > 0 {
+		tlsConfig = pinnedTLSConfig(tlsConfig, c.options.certificatePins)
+	}
+
+	// Configure transport with connection pool settings
+	transport := &http.Transport{
+		MaxIdleConns:           c.options.maxIdleConns,
+		MaxConnsPerHost:        c.options.maxConnsPerHost,
+		IdleConnTimeout:        c.options.idleConnTimeout,
+		DisableKeepAlives:      c.options.disableKeepAlive,
+		DisableCompression:     !c.options.acceptCompression,
+		MaxResponseHeaderBytes: int64(c.options.maxResponseHeaders),
+		TLSClientConfig:        tlsConfig,
+	}
+
+	transport.DialContext = c.newDialContext()
+
+	return transport
+}
+
+// newDialContext builds the dialer chain of the dial options: the dial
+// network, pool stats, connection lifetime and validation, in that order
+// from the inside out. It returns nil when none of them is set, because a
+// custom dialer turns off HTTP/2 in net/http.
+func (c *Client) newDialContext() dialFunc {
+	if c.options.dialNetwork == defaultDialNetwork && !c.options.poolStats &&
+		c.options.connMaxLifetime == 0 && !c.options.validateConnOnUse {
+		return nil
+	}
+
+	dial := forceNetwork((&net.Dialer{}).DialContext, c.options.dialNetwork)
+
+	if c.options.poolStats {
+		dial = c.countingDialContext(dial)
+	}
+
+	if c.options.connMaxLifetime > 0 {
+		dial = lifetimeDialContext(dial, c.options.connMaxLifetime)
+	}
+
+	if c.options.validateConnOnUse {
+		dial = validatingDialContext(dial, c.options.clock)
+	}
+
+	return dial
+}
+
+// forceNetwork wraps dial so that it always dials over network, whatever the
+// transport asks for, see [WithDialNetwork].
+func forceNetwork(dial dialFunc, network string) dialFunc {
+	if network == defaultDialNetwork {
+		return dial
+	}
+
+	return func(ctx context.Context, _, addr string) (net.Conn, error) {
+		return dial(ctx, network, addr)
+	}
+}
+
 // Send posts one or more alerts to the API. [Client.Connect] must be called
 // first. Returns an error if the alerts slice is empty or any element is nil.
 func (c *Client) Send(ctx context.Context, alerts ...*types.Alert) error {
@@ -123,12 +350,121 @@ func (c *Client) Send(ctx context.Context, alerts ...*types.Alert) error {
 	return err
 }
 
+// TrySend posts one or more alerts like [Client.Send] when the client is
+// ready, for best-effort alerting in hot paths. If [Client.Connect] has not
+// completed successfully, or the [WithDrainContext] context is done, it
+// returns (false, nil) straight away without sending. Otherwise sent reports
+// whether the send succeeded, with err holding its error.
+func (c *Client) TrySend(ctx context.Context, alerts ...*types.Alert) (sent bool, err error) {
+	if c == nil {
+		return false, errors.New("alert client is nil")
+	}
+
+	if !c.connected.Load() {
+		return false, nil
+	}
+
+	// Draining is detected from the send error rather than checked up
+	// front, so that a drain starting concurrently is also a skipped send.
+	if err := c.Send(ctx, alerts...); err != nil {
+		if errors.Is(err, ErrDraining) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
 // SendWithResponse posts one or more alerts to the API and returns HTTP response metadata.
 // [Client.Connect] must be called first. Returns an error if the alerts slice is empty or
 // any element is nil. The returned *ResponseMetadata is non-nil whenever an HTTP response
 // was received (even on non-2xx); it is nil only when a network-level error prevents any
 // response from arriving.
+//
+// When [WithMaxSendDuration] is set, the whole send is bounded by that duration and an
+// error wrapping [ErrSendDeadlineExceeded] is returned if it is exceeded.
+//
+// When [WithSeverityEndpoint] splits the alerts across several endpoints, one request is
+// made per endpoint and the errors of all failed groups are joined. The metadata is then
+// that of the first failed group, or of the last group if all succeeded.
 func (c *Client) SendWithResponse(ctx context.Context, alerts ...*types.Alert) (*ResponseMetadata, error) {
+	return c.send(ctx, nil, alerts)
+}
+
+// Resolve posts one or more alerts to the API marked as resolved, clearing
+// the issues they previously raised. The marker is a query parameter,
+// "state=resolved" by default, configurable with [WithResolveQueryParam].
+// Otherwise Resolve behaves exactly like [Client.Send].
+func (c *Client) Resolve(ctx context.Context, alerts ...*types.Alert) error {
+	if c == nil {
+		return errors.New("alert client is nil")
+	}
+
+	call := &callOptions{
+		queryParams: map[string]string{c.options.resolveParam: c.options.resolveValue},
+	}
+
+	_, err := c.send(ctx, call, alerts)
+
+	return err
+}
+
+// SendToChannel posts one or more alerts to the API, overriding the target
+// Slack channel for this call only via the X-Slack-Channel header. The
+// channel must be a valid Slack channel ID or name. Otherwise SendToChannel
+// behaves exactly like [Client.Send], which leaves routing to the server.
+func (c *Client) SendToChannel(ctx context.Context, channel string, alerts ...*types.Alert) error {
+	channel = strings.TrimSpace(channel)
+
+	if channel == "" {
+		return errors.New("channel must not be empty")
+	}
+
+	if !types.SlackChannelIDOrNameRegex.MatchString(channel) {
+		return fmt.Errorf("invalid channel %q", channel)
+	}
+
+	call := &callOptions{
+		headers: map[string]string{channelHeader: channel},
+	}
+
+	_, err := c.send(ctx, call, alerts)
+
+	return err
+}
+
+// SendWithHeaders posts one or more alerts to the API with additional headers
+// for this call only, such as a trace or tenant ID. They are merged over the
+// headers configured with [WithRequestHeader] without changing the client's
+// configuration. As with [WithRequestHeader], names and values are trimmed,
+// and empty names and the protected Content-Type and Accept headers are
+// silently ignored. Otherwise SendWithHeaders behaves exactly like
+// [Client.Send].
+func (c *Client) SendWithHeaders(ctx context.Context, headers map[string]string, alerts ...*types.Alert) error {
+	call := &callOptions{
+		headers: make(map[string]string, len(headers)),
+	}
+
+	for header, value := range headers {
+		header = strings.TrimSpace(header)
+
+		if header == "" || isProtectedHeader(header) {
+			continue
+		}
+
+		call.headers[header] = strings.TrimSpace(value)
+	}
+
+	_, err := c.send(ctx, call, alerts)
+
+	return err
+}
+
+// send validates alerts and passes them through the send middleware chain,
+// applying the per-call settings in call (which may be nil).
+func (c *Client) send(ctx context.Context, call *callOptions, alerts []*types.Alert) (meta *ResponseMetadata, err error) {
 	if c == nil {
 		return nil, errors.New("alert client is nil")
 	}
@@ -137,36 +473,183 @@ func (c *Client) SendWithResponse(ctx context.Context, alerts ...*types.Alert) (
 		return nil, errors.New("client not connected - call Connect() first")
 	}
 
+	if c.options.drainCtx != nil && c.options.drainCtx.Err() != nil {
+		return nil, ErrDraining
+	}
+
+	if err = validateAlerts(alerts); err != nil {
+		return nil, err
+	}
+
+	if err = c.runAlertValidator(alerts); err != nil {
+		return nil, err
+	}
+
+	if c.options.minSeverity != "" {
+		if alerts = c.filterBySeverity(alerts); len(alerts) == 0 {
+			return nil, ErrNoAlertsAfterFilter
+		}
+	}
+
+	if threshold := c.options.largeBatchThreshold; threshold > 0 && len(alerts) > threshold {
+		c.options.requestLogger.Warnf("sending %d alerts in one batch, above the warning threshold of %d", len(alerts), threshold)
+	}
+
+	if c.options.maxSendDuration > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeoutCause(ctx, c.options.maxSendDuration, ErrSendDeadlineExceeded)
+		defer cancel()
+	}
+
+	if c.options.observer != nil {
+		var send *observedSend
+
+		ctx, send = c.observeStart(ctx, len(alerts))
+
+		defer func() { c.observeEnd(send, meta, err) }()
+	}
+
+	// undelivered holds the alerts of the requests that failed in the last
+	// delivery, as a middleware may deliver more than once.
+	var undelivered []*types.Alert
+
+	deliver := func(ctx context.Context, alerts ...*types.Alert) error {
+		var deliverErr error
+
+		meta, undelivered, deliverErr = c.deliver(ctx, call, alerts)
+
+		return deliverErr
+	}
+
+	err = c.buildSendChain(deliver)(ctx, alerts...)
+	if err != nil && errors.Is(context.Cause(ctx), ErrSendDeadlineExceeded) {
+		err = fmt.Errorf("%w after %v: %w", ErrSendDeadlineExceeded, c.options.maxSendDuration, err)
+	}
+
+	if err != nil && len(undelivered) > 0 && c.options.deliveryFailureSink != nil {
+		c.options.deliveryFailureSink(ctx, undelivered, err)
+	}
+
+	return meta, err
+}
+
+// deliver prepares alerts and posts them to the API. It is the innermost step
+// of the send middleware chain, so the alerts are validated again in case a
+// middleware modified them. It also returns the prepared alerts of the
+// requests that failed.
+func (c *Client) deliver(ctx context.Context, call *callOptions, alerts []*types.Alert) (*ResponseMetadata, []*types.Alert, error) {
+	if err := validateAlerts(alerts); err != nil {
+		return nil, nil, err
+	}
+
+	alerts, err := c.prepareAlerts(alerts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.sendGroups(ctx, call, alerts)
+}
+
+func validateAlerts(alerts []*types.Alert) error {
 	if len(alerts) == 0 {
-		return nil, errors.New("alerts list cannot be empty")
+		return errors.New("alerts list cannot be empty")
 	}
 
 	for i, alert := range alerts {
 		if alert == nil {
-			return nil, fmt.Errorf("alert at index %d is nil", i)
+			return fmt.Errorf("alert at index %d is nil", i)
 		}
 	}
 
-	alertsInput := &alertsList{
-		Alerts: alerts,
+	return nil
+}
+
+// runAlertValidator runs the validator set with [WithAlertValidator] on each
+// alert, returning the error of the first alert it rejects.
+func (c *Client) runAlertValidator(alerts []*types.Alert) error {
+	if c.options.alertValidator == nil {
+		return nil
 	}
 
-	body, err := json.Marshal(alertsInput)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal alerts list: %w", err)
+	for i, alert := range alerts {
+		if err := c.options.alertValidator(alert); err != nil {
+			return fmt.Errorf("alert at index %d: %w", i, err)
+		}
 	}
 
-	return c.postWithResponse(ctx, c.options.alertsEndpoint, body)
+	return nil
+}
+
+// sendGroups sends alerts to their destination endpoints, one request per
+// endpoint group, and returns the alerts of the groups that failed.
+func (c *Client) sendGroups(ctx context.Context, call *callOptions, alerts []*types.Alert) (*ResponseMetadata, []*types.Alert, error) {
+	groups := c.groupAlerts(alerts)
+
+	if len(c.options.severityEndpoints) == 0 {
+		meta, err := c.sendAlerts(ctx, call, groups[0].endpoint, groups[0].alerts)
+		if err != nil {
+			return meta, groups[0].alerts, err
+		}
+
+		return meta, nil, nil
+	}
+
+	var (
+		meta   *ResponseMetadata
+		failed []*types.Alert
+		errs   []error
+	)
+
+	for _, group := range groups {
+		groupMeta, err := c.sendAlerts(ctx, call, group.endpoint, group.alerts)
+		if err != nil {
+			if len(errs) == 0 {
+				meta = groupMeta
+			}
+
+			failed = append(failed, group.alerts...)
+			errs = append(errs, fmt.Errorf("severity group %q: %w", strings.Join(group.severities, ","), err))
+
+			continue
+		}
+
+		if len(errs) == 0 {
+			meta = groupMeta
+		}
+	}
+
+	return meta, failed, errors.Join(errs...)
 }
 
 // Close releases idle connections held by the client. After Close is called
-// the client should not be reused.
+// the client should not be reused. Requests in flight are not interrupted;
+// use [Client.CloseWithGrace] to wait for them. A transport shared with
+// [WithSharedTransport] is left untouched, as its owner closes it.
 func (c *Client) Close() {
-	if c.transport != nil {
+	if c.transport != nil && c.options.sharedTransport == nil {
 		c.transport.CloseIdleConnections()
 	}
 }
 
+// CloseWithGrace waits up to grace for requests in flight to complete before
+// releasing the client's connections like [Client.Close]. Requests still in
+// flight when the grace period elapses are cancelled, failing with a context
+// cancellation error. A grace of zero or less cancels them immediately.
+// After CloseWithGrace is called the client should not be reused.
+func (c *Client) CloseWithGrace(grace time.Duration) {
+	if c == nil || c.transport == nil {
+		return
+	}
+
+	if !c.inFlight.wait(grace) {
+		c.options.requestLogger.Warnf("grace period of %v elapsed with %d requests in flight, cancelling them", grace, c.inFlight.count())
+		c.inFlight.cancelAll()
+	}
+
+	c.Close()
+}
+
 // Ping checks connectivity to the API. [Client.Connect] must be called
 // first. Use this to verify the connection is still healthy after the
 // initial connect.
@@ -179,7 +662,9 @@ func (c *Client) Ping(ctx context.Context) error {
 		return errors.New("client not connected - call Connect() first")
 	}
 
-	return c.ping(ctx)
+	_, err := c.ping(ctx)
+
+	return err
 }
 
 // RestyClient returns the underlying resty.Client for advanced configuration.
@@ -189,46 +674,336 @@ func (c *Client) RestyClient() *resty.Client {
 	return c.client
 }
 
-func (c *Client) ping(ctx context.Context) error {
-	return c.get(ctx, c.options.pingEndpoint)
+// groupAlerts partitions alerts by destination endpoint, preserving the order
+// in which endpoints and alerts are first seen. Without severity routing all
+// alerts form a single group for the default alerts endpoint.
+func (c *Client) groupAlerts(alerts []*types.Alert) []*alertGroup {
+	defaultEndpoint := c.resolveAlertsEndpoint(alerts)
+
+	if len(c.options.severityEndpoints) == 0 {
+		return []*alertGroup{{endpoint: defaultEndpoint, alerts: alerts}}
+	}
+
+	var groups []*alertGroup
+
+	byEndpoint := make(map[string]*alertGroup)
+
+	for _, alert := range alerts {
+		severity := string(alert.Severity)
+
+		endpoint, ok := c.options.severityEndpoints[severity]
+		if !ok {
+			endpoint = defaultEndpoint
+		}
+
+		group, ok := byEndpoint[endpoint]
+		if !ok {
+			group = &alertGroup{endpoint: endpoint}
+			byEndpoint[endpoint] = group
+			groups = append(groups, group)
+		}
+
+		if !slices.Contains(group.severities, severity) {
+			group.severities = append(group.severities, severity)
+		}
+
+		group.alerts = append(group.alerts, alert)
+	}
+
+	return groups
 }
 
-func (c *Client) get(ctx context.Context, path string) error {
-	request := c.client.R().SetContext(ctx)
+// resolveAlertsEndpoint returns the default endpoint for alerts: the one
+// computed by the [WithAlertsEndpointResolver] callback, or the configured
+// alerts endpoint when there is no resolver or it returns an empty path.
+func (c *Client) resolveAlertsEndpoint(alerts []*types.Alert) string {
+	if c.options.endpointResolver == nil {
+		return c.options.alertsEndpoint
+	}
 
-	response, err := request.Get(path)
+	if endpoint := strings.TrimSpace(c.options.endpointResolver(alerts)); endpoint != "" {
+		return endpoint
+	}
+
+	return c.options.alertsEndpoint
+}
+
+// sendAlerts marshals alerts into the request envelope and posts them to endpoint.
+func (c *Client) sendAlerts(ctx context.Context, call *callOptions, endpoint string, alerts []*types.Alert) (*ResponseMetadata, error) {
+	endpoint = c.versionedEndpoint(endpoint)
+
+	if c.encrypter != nil {
+		encrypted, err := c.encrypter.encrypt(alerts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt alert fields: %w", err)
+		}
+
+		alerts = encrypted
+		call = call.withHeader(encryptedFieldsHeader, c.encrypter.names)
+	}
+
+	alertsInput := &alertsList{
+		Alerts: alerts,
+		Meta:   c.envelopeMeta,
+	}
+
+	if c.options.streamingEncode {
+		ctx = withStreamingBody(ctx, &streamingBody{payload: alertsInput, indent: c.options.indentJSON})
+		return c.postWithResponse(ctx, call, endpoint, nil)
+	}
+
+	body, err := c.marshal(alertsInput)
 	if err != nil {
-		return fmt.Errorf("GET %s failed: %w", path, err)
+		return nil, fmt.Errorf("failed to marshal alerts list: %w", err)
 	}
 
-	if !response.IsSuccess() {
-		return fmt.Errorf("GET %s failed with status code %d: %s", sanitizeURL(response.Request.URL), response.StatusCode(), getBodyErrorMessage(response))
+	return c.postWithResponse(ctx, call, endpoint, body)
+}
+
+// marshal encodes v as a request body, indented when [WithIndentedJSON] is
+// enabled.
+func (c *Client) marshal(v any) ([]byte, error) {
+	if c.options.indentJSON {
+		return json.MarshalIndent(v, "", "  ")
+	}
+
+	return json.Marshal(v)
+}
+
+// connectPing pings the API, tolerating the configured number of consecutive
+// failures with a fixed wait between attempts. It returns the body of the
+// successful ping.
+func (c *Client) connectPing(ctx context.Context) ([]byte, error) {
+	response, err := c.ping(ctx)
+
+	for failures := 1; err != nil && failures <= c.options.pingTolerance; failures++ {
+		c.options.requestLogger.Warnf("ping failed (%d of %d tolerated failures), retrying in %v: %v", failures, c.options.pingTolerance, c.options.pingInterval, err)
+
+		timer := time.NewTimer(c.options.pingInterval)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errors.Join(err, ctx.Err())
+		}
+
+		response, err = c.ping(ctx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body(), nil
+}
+
+// checkConnectFallback checks the endpoint set with
+// [WithConnectFallbackEndpoint] after the ping failed with pingErr. It
+// returns nil if the fallback succeeds, and both errors otherwise.
+func (c *Client) checkConnectFallback(ctx context.Context, pingErr error) error {
+	fallback := c.options.connectFallback
+
+	if err := c.check(ctx, fallback.method, fallback.path); err != nil {
+		return errors.Join(pingErr, fmt.Errorf("connect fallback failed: %w", err))
 	}
 
+	c.options.requestLogger.Warnf("ping failed, connected after a successful %s %s instead: %v", fallback.method, fallback.path, pingErr)
+
 	return nil
 }
 
-func (c *Client) postWithResponse(ctx context.Context, path string, body []byte) (*ResponseMetadata, error) {
-	request := c.client.R().SetContext(ctx).SetBody(body)
+func (c *Client) ping(ctx context.Context) (*resty.Response, error) {
+	response, err := c.fetch(ctx, resty.MethodGet, c.options.pingEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected := c.options.expectedPingBody; expected != "" && !strings.Contains(string(response.Body()), expected) {
+		return nil, fmt.Errorf("GET %s returned status code %d without the expected body %q: %s", sanitizeURL(response.Request.URL), response.StatusCode(), expected, truncateMessage(string(response.Body()), maxErrorLineLength))
+	}
+
+	return response, nil
+}
+
+// runConnectProbes checks every endpoint configured with [WithConnectProbes]
+// and joins the errors of all probes that failed.
+func (c *Client) runConnectProbes(ctx context.Context) error {
+	var errs []error
+
+	for _, probe := range c.options.connectProbes {
+		if err := c.check(ctx, probe.method, probe.path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// check makes a body-less request and returns an error unless it succeeds
+// with a 2xx status.
+func (c *Client) check(ctx context.Context, method, path string) error {
+	_, err := c.fetch(ctx, method, path)
+	return err
+}
+
+// fetch makes a body-less request and returns the response, or an error
+// unless it succeeds with a 2xx status.
+func (c *Client) fetch(ctx context.Context, method, path string) (*resty.Response, error) {
+	response, err := c.execute(ctx, nil, method, path, nil)
+	if err != nil {
+		return nil, requestError(method, path, err)
+	}
+
+	if err := c.verifyResponseChecksum(response); err != nil {
+		return response, err
+	}
+
+	if !response.IsSuccess() {
+		return response, c.newAPIError(method, response)
+	}
+
+	return response, nil
+}
+
+func (c *Client) postWithResponse(ctx context.Context, call *callOptions, path string, body []byte) (*ResponseMetadata, error) {
+	if err := c.validatePayload(body); err != nil {
+		return nil, err
+	}
+
+	if c.throttle != nil {
+		if err := c.throttle.wait(ctx); err != nil {
+			return nil, fmt.Errorf("POST %s throttled: %w", path, err)
+		}
+	}
+
+	if c.inFlightBytes != nil {
+		acquired, err := c.inFlightBytes.acquire(ctx, int64(len(body)))
+		if err != nil {
+			return nil, fmt.Errorf("POST %s waiting for in-flight capacity: %w", path, err)
+		}
+
+		defer c.inFlightBytes.release(acquired)
+	}
+
+	response, err := c.execute(ctx, call, resty.MethodPost, path, body)
+
+	// Only responses count towards the throttle. A request error may be the
+	// caller's own cancellation or a dial or DNS failure on this side, which
+	// says nothing about the server.
+	if c.throttle != nil && err == nil && ctx.Err() == nil {
+		c.throttle.record(response.StatusCode())
+	}
 
-	response, err := request.Post(path)
 	if err != nil {
-		return nil, fmt.Errorf("POST %s failed: %w", path, err)
+		return nil, requestError(resty.MethodPost, path, err)
 	}
 
 	meta := &ResponseMetadata{
-		Duration:   response.Time(),
-		StatusCode: response.StatusCode(),
-		Headers:    flattenHeaders(response.Header()),
+		Duration:        response.Time(),
+		TimeToFirstByte: timeToFirstByte(response),
+		StatusCode:      response.StatusCode(),
+		Headers:         flattenHeaders(response.Header()),
+	}
+
+	if err := c.verifyResponseChecksum(response); err != nil {
+		return meta, err
 	}
 
 	if !response.IsSuccess() {
-		return meta, fmt.Errorf("POST %s failed with status code %d: %s", sanitizeURL(response.Request.URL), response.StatusCode(), getBodyErrorMessage(response))
+		return meta, c.newAPIError(resty.MethodPost, response)
+	}
+
+	if err := c.checkResponseVersion(response); err != nil {
+		return meta, err
+	}
+
+	if substring, ok := c.retryableBodyMatch(response); ok {
+		return meta, fmt.Errorf("POST %s returned status code %d with retryable body content %q after all retries", sanitizeURL(response.Request.URL), response.StatusCode(), substring)
+	}
+
+	if c.options.bodyErrorCheck != nil {
+		if err := c.options.bodyErrorCheck(ctx, response.Body()); err != nil {
+			return meta, fmt.Errorf("POST %s returned status code %d with an error in the body: %w", sanitizeURL(response.Request.URL), response.StatusCode(), err)
+		}
+	}
+
+	if call != nil && call.onSuccess != nil {
+		call.onSuccess(response)
 	}
 
 	return meta, nil
 }
 
+// execute performs a single logical request (including any retries) against
+// path, applying the per-call settings in call (which may be nil). A nil body
+// sends no request body.
+func (c *Client) execute(ctx context.Context, call *callOptions, method, path string, body []byte) (*resty.Response, error) {
+	if _, ok := ctx.Deadline(); !ok && c.options.defaultRequestTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.options.defaultRequestTimeout)
+		defer cancel()
+	}
+
+	ctx, done := c.inFlight.start(ctx)
+	defer done()
+
+	tracer := &requestTracer{}
+	ctx = tracer.withTrace(ctx)
+
+	if c.options.poolStats {
+		usage := &connUsage{client: c}
+		ctx = usage.withTrace(ctx)
+
+		defer usage.release()
+	}
+
+	if c.options.connMaxLifetime > 0 {
+		usage := &lifetimeUsage{}
+		ctx = usage.withTrace(ctx)
+
+		defer usage.release()
+	}
+
+	if method == resty.MethodPost {
+		ctx = (&writeTracker{}).withTrace(ctx)
+	}
+
+	request := c.client.R().SetContext(ctx)
+	defer c.plannedWaits.Delete(request)
+
+	if body != nil {
+		request.SetBody(body)
+	}
+
+	if call != nil {
+		request.SetQueryParams(call.queryParams)
+		request.SetHeaders(call.headers)
+	}
+
+	response, err := request.Execute(method, path)
+
+	c.audit(method, path, body, response, err)
+
+	timings := tracer.result()
+	c.lastTTFB.Store(int64(timings.TimeToFirstByte))
+
+	if c.options.timingCallback != nil {
+		c.options.timingCallback(ctx, timings)
+	}
+
+	if c.options.statusFamilyFn != nil && response != nil && response.RawResponse != nil {
+		c.options.statusFamilyFn(ctx, response.StatusCode()/100)
+	}
+
+	if c.options.statusLogging && response != nil && response.RawResponse != nil {
+		c.logStatus(response)
+	}
+
+	return response, err
+}
+
 func flattenHeaders(h http.Header) map[string]string {
 	headers := make(map[string]string, len(h))
 	for key, values := range h {
@@ -238,19 +1013,81 @@ func flattenHeaders(h http.Header) map[string]string {
 	return headers
 }
 
-func getBodyErrorMessage(response *resty.Response) string {
+// getBodyErrorMessage builds a concise error message from an error response.
+// HTML bodies, typically error pages injected by a proxy, are summarised.
+// Otherwise the message found at the dot-separated path in a JSON body is
+// used, falling back to the raw body truncated to maxErrorMessageLength.
+func getBodyErrorMessage(response *resty.Response, path string) string {
 	body := response.Body()
 
 	if len(body) == 0 {
 		return "(empty error body)"
 	}
 
-	var apiErr apiErrorResponse
-	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error != "" {
-		return apiErr.Error
+	if isHTMLContentType(response.Header().Get("Content-Type")) {
+		return fmt.Sprintf("server returned an HTML error page (%d bytes): %s", len(body), truncateMessage(firstLine(body), maxErrorLineLength))
+	}
+
+	if message, ok := lookupJSONString(body, path); ok {
+		return message
+	}
+
+	return truncateMessage(string(body), maxErrorMessageLength)
+}
+
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml")
+}
+
+// firstLine returns the first non-blank line of body, trimmed of whitespace.
+func firstLine(body []byte) string {
+	for line := range strings.Lines(string(body)) {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
+
+// truncateMessage shortens s to at most maxLen bytes without splitting a
+// UTF-8 sequence, noting the original length when truncated.
+func truncateMessage(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s... (truncated, %d bytes total)", s[:cut], len(s))
+}
+
+// lookupJSONString returns the non-empty string value found by following the
+// dot-separated object keys in path through the JSON document in body.
+func lookupJSONString(body []byte, path string) (string, bool) {
+	var current any
+	if err := json.Unmarshal(body, &current); err != nil {
+		return "", false
+	}
+
+	for key := range strings.SplitSeq(path, ".") {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return "", false
+		}
+
+		if current, ok = object[key]; !ok {
+			return "", false
+		}
 	}
 
-	return string(body)
+	value, ok := current.(string)
+
+	return value, ok && value != ""
 }
 
 // sanitizeURL removes credentials (user info) from URLs to prevent leaking in logs.
@@ -273,23 +1110,45 @@ func sanitizeURL(rawURL string) string {
 	return result
 }
 
-// parseRetryAfterHeader extracts the Retry-After header value for rate limiting.
-// Returns the duration to wait before retrying if the header is present.
-func parseRetryAfterHeader(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
-	retryAfter := resp.Header().Get("Retry-After")
-	if retryAfter == "" {
-		return 0, nil
+// parseRetryAfterHeader extracts the Retry-After header value from a response
+// that is about to be retried. It applies to any status the retry policy
+// retries, such as 429, 503 or a 500 during maintenance, not just rate
+// limiting. Returns the duration to wait before retrying if the header is
+// present; [Client.retryWait] caps it. A missing, invalid or already elapsed
+// value returns 0, falling back to the regular exponential backoff.
+//
+// An HTTP-date is measured from the Date header of the response, so that
+// the wait depends on the server's clock alone and a wrong or jumping local
+// clock does not stretch or shrink it. Only when the response has no valid
+// Date header is it measured from clock.
+func parseRetryAfterHeader(resp *resty.Response, clock Clock) (time.Duration, error) {
+	return parseDelayHeader(resp.Header(), "Retry-After", clock), nil
+}
+
+// parseDelayHeader parses the header name of a response as a delay in the
+// Retry-After format, either a number of seconds or an HTTP-date, as
+// described at [parseRetryAfterHeader]. A missing, invalid or already
+// elapsed value returns 0.
+func parseDelayHeader(header http.Header, name string, clock Clock) time.Duration {
+	value := header.Get(name)
+	if value == "" {
+		return 0
 	}
 
 	// Try parsing as seconds first
-	if seconds, err := strconv.Atoi(retryAfter); err == nil {
-		return time.Duration(seconds) * time.Second, nil
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
 	}
 
 	// Try parsing as HTTP-date
-	if t, err := http.ParseTime(retryAfter); err == nil {
-		return time.Until(t), nil
+	if t, err := http.ParseTime(value); err == nil {
+		now, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			now = clock.Now()
+		}
+
+		return max(t.Sub(now), 0)
 	}
 
-	return 0, nil
+	return 0
 }
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+// reloadingTLSConfig returns a copy of config (which may be nil) that asks
+// reload for the client certificate on every handshake.
+func reloadingTLSConfig(config *tls.Config, reload func() (tls.Certificate, error)) *tls.Config {
+	if config == nil {
+		config = &tls.Config{} //nolint:gosec // MinVersion defaults to TLS 1.2 for clients
+	} else {
+		config = config.Clone()
+	}
+
+	config.GetClientCertificate = func(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		cert, err := reload()
+		if err != nil {
+			return nil, fmt.Errorf("failed to reload client certificate: %w", err)
+		}
+
+		return &cert, nil
+	}
+
+	return config
+}
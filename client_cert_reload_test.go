@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newClientCertificate returns a self-signed client certificate with the
+// given common name.
+func newClientCertificate(t *testing.T, commonName string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// newMTLSServer returns a TLS server that requires a client certificate and
+// records the common name of each one it is presented.
+func newMTLSServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		names []string
+	)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		names = append(names, r.TLS.PeerCertificates[0].Subject.CommonName)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{MinVersion: tls.VersionTLS12, ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return names
+	}
+}
+
+func TestClientCertificateReloader(t *testing.T) {
+	t.Parallel()
+
+	server, names := newMTLSServer(t)
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	var current atomic.Pointer[tls.Certificate]
+
+	first := newClientCertificate(t, "first")
+	current.Store(&first)
+
+	c := New(server.URL, WithTLSConfig(tlsConfig), WithClientCertificateReloader(func() (tls.Certificate, error) {
+		return *current.Load(), nil
+	}))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	rotated := newClientCertificate(t, "rotated")
+	current.Store(&rotated)
+
+	// Force a new handshake for the next request.
+	c.transport.CloseIdleConnections()
+
+	if err := c.Send(context.Background(), &types.Alert{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.Join(names(), ","); got != "first,rotated" {
+		t.Errorf("expected the rotated certificate on the new connection, got %s", got)
+	}
+}
+
+func TestClientCertificateReloader_Error(t *testing.T) {
+	t.Parallel()
+
+	server, names := newMTLSServer(t)
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	c := New(server.URL, WithTLSConfig(tlsConfig), WithRetryCount(0), WithClientCertificateReloader(func() (tls.Certificate, error) {
+		return tls.Certificate{}, errors.New("certificate file not found")
+	}))
+
+	err := c.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to reload client certificate: certificate file not found") {
+		t.Errorf("expected the reloader error, got: %v", err)
+	}
+
+	if got := names(); len(got) != 0 {
+		t.Errorf("expected no request to reach the server, got %v", got)
+	}
+}
+
+func TestReloadingTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	base := &tls.Config{MinVersion: tls.VersionTLS13}
+
+	config := reloadingTLSConfig(base, func() (tls.Certificate, error) { return tls.Certificate{}, nil })
+
+	if config.MinVersion != tls.VersionTLS13 || config.GetClientCertificate == nil {
+		t.Errorf("expected the base configuration with a certificate callback, got %+v", config)
+	}
+
+	if base.GetClientCertificate != nil {
+		t.Error("expected the base configuration not to be modified")
+	}
+}
@@ -1,12 +1,21 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -32,6 +41,70 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewFromOptions(t *testing.T) {
+	t.Parallel()
+
+	base := DefaultOptions().Apply(WithRetryCount(5), WithRequestHeader("X-Team", "payments"))
+
+	client := NewFromOptions("http://example.com", base)
+
+	if client.baseURL != "http://example.com" {
+		t.Errorf("expected baseURL=http://example.com, got %s", client.baseURL)
+	}
+
+	if client.options.retryCount != 5 || client.options.requestHeaders["X-Team"] != "payments" {
+		t.Errorf("expected the base options to be used, got %+v", client.options)
+	}
+
+	// Later changes to the base must not leak into the client
+	base.Apply(WithRetryCount(1), WithRequestHeader("X-Team", "search"))
+
+	if client.options.retryCount != 5 || client.options.requestHeaders["X-Team"] != "payments" {
+		t.Errorf("expected the client to keep its own copy, got %+v", client.options)
+	}
+}
+
+func TestNewFromOptions_Nil(t *testing.T) {
+	t.Parallel()
+
+	client := NewFromOptions("http://example.com", nil)
+
+	if client.options == nil || client.options.retryCount != 3 {
+		t.Errorf("expected the default options, got %+v", client.options)
+	}
+}
+
+func TestNewTransport_DefaultNegotiatesHTTP2(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	transport := New(server.URL).newTransport()
+
+	// Let the transport set up its protocols before trusting the test
+	// certificate, which would otherwise count as a custom TLS config
+	transport.CloseIdleConnections()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	transport.TLSClientConfig.RootCAs = pool
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.ProtoMajor != 2 {
+		t.Errorf("expected HTTP/2 with the default options, got %s", resp.Proto)
+	}
+}
+
 func TestConnect_EmptyURL(t *testing.T) {
 	t.Parallel()
 
@@ -882,7 +955,7 @@ func TestParseRetryAfterHeader(t *testing.T) {
 		defer server.Close()
 
 		resp := makeRestyRequest(t, server.URL)
-		duration, err := parseRetryAfterHeader(nil, resp)
+		duration, err := parseRetryAfterHeader(resp, systemClock{})
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -901,7 +974,7 @@ func TestParseRetryAfterHeader(t *testing.T) {
 		defer server.Close()
 
 		resp := makeRestyRequest(t, server.URL)
-		duration, err := parseRetryAfterHeader(nil, resp)
+		duration, err := parseRetryAfterHeader(resp, systemClock{})
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -924,7 +997,7 @@ func TestParseRetryAfterHeader(t *testing.T) {
 		defer server.Close()
 
 		resp := makeRestyRequest(t, server.URL)
-		duration, err := parseRetryAfterHeader(nil, resp)
+		duration, err := parseRetryAfterHeader(resp, systemClock{})
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -934,6 +1007,46 @@ func TestParseRetryAfterHeader(t *testing.T) {
 		}
 	})
 
+	t.Run("service unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		resp := makeRestyRequest(t, server.URL)
+		duration, err := parseRetryAfterHeader(resp, systemClock{})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if duration != 30*time.Second {
+			t.Errorf("expected 30s, got %v", duration)
+		}
+	})
+
+	t.Run("elapsed http-date returns zero", func(t *testing.T) {
+		t.Parallel()
+
+		httpDate := time.Now().Add(-60 * time.Second).UTC().Format(http.TimeFormat)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Retry-After", httpDate)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		resp := makeRestyRequest(t, server.URL)
+		duration, err := parseRetryAfterHeader(resp, systemClock{})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if duration != 0 {
+			t.Errorf("expected 0 duration for elapsed date, got %v", duration)
+		}
+	})
+
 	t.Run("invalid format returns zero", func(t *testing.T) {
 		t.Parallel()
 
@@ -944,7 +1057,7 @@ func TestParseRetryAfterHeader(t *testing.T) {
 		defer server.Close()
 
 		resp := makeRestyRequest(t, server.URL)
-		duration, err := parseRetryAfterHeader(nil, resp)
+		duration, err := parseRetryAfterHeader(resp, systemClock{})
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -1159,3 +1272,1618 @@ func makeRestyRequest(t *testing.T, url string) *resty.Response {
 
 	return resp
 }
+
+// recordedRequest is a request to /alerts recorded by [newRecordingClient].
+type recordedRequest struct {
+	body          []byte
+	contentLength int64
+	query         url.Values
+}
+
+// texts returns the texts of the alerts in the request body.
+func (r recordedRequest) texts() []string {
+	var payload alertsList
+	_ = json.Unmarshal(r.body, &payload)
+
+	texts := make([]string, 0, len(payload.Alerts))
+	for _, alert := range payload.Alerts {
+		texts = append(texts, alert.Text)
+	}
+
+	return texts
+}
+
+// requestRecorder holds the requests recorded by [newRecordingClient].
+type requestRecorder struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+// all returns the requests recorded so far.
+func (r *requestRecorder) all() []recordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.requests)
+}
+
+// take returns the requests recorded since the last call to take.
+func (r *requestRecorder) take() []recordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	taken := r.requests
+	r.requests = nil
+
+	return taken
+}
+
+// replyWith returns a responder for [newRecordingClient] that answers every
+// request with status and body.
+func replyWith(status int, body string) func(attempt int) (int, string) {
+	return func(int) (int, string) {
+		return status, body
+	}
+}
+
+// newRecordingClient returns a connected client whose server records the
+// requests posted to /alerts. respond gives the status and body of each of
+// them from the 1-based attempt number; a nil respond answers 200 OK. Other
+// paths, such as the ping, answer 200 OK. Retries are disabled unless opts
+// enable them.
+func newRecordingClient(t *testing.T, respond func(attempt int) (int, string), opts ...Option) (*Client, *requestRecorder) {
+	t.Helper()
+
+	var (
+		recorder requestRecorder
+		attempts atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+
+		recorder.mu.Lock()
+		recorder.requests = append(recorder.requests, recordedRequest{body: body, contentLength: r.ContentLength, query: r.URL.Query()})
+		recorder.mu.Unlock()
+
+		if respond == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		status, reply := respond(int(attempts.Add(1)))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(reply))
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, append([]Option{WithRetryCount(0)}, opts...)...)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	return c, &recorder
+}
+
+func TestSend_SeverityEndpoints(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		received = map[string][]string{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var payload alertsList
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		mu.Lock()
+		for _, alert := range payload.Alerts {
+			received[r.URL.Path] = append(received[r.URL.Path], alert.Header)
+		}
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithSeverityEndpoint("panic", "critical-alerts"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(),
+		&types.Alert{Header: "a", Severity: types.AlertInfo},
+		&types.Alert{Header: "b", Severity: types.AlertPanic},
+		&types.Alert{Header: "c", Severity: types.AlertWarning},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := received["/critical-alerts"]; len(got) != 1 || got[0] != "b" {
+		t.Errorf("expected [b] at /critical-alerts, got %v", got)
+	}
+
+	if got := received["/alerts"]; len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Errorf("expected [a c] at /alerts, got %v", got)
+	}
+}
+
+func TestSend_SeverityEndpoints_GroupFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/critical-alerts" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"rejected"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetryCount(0), WithSeverityEndpoint("panic", "critical-alerts"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	meta, err := c.SendWithResponse(context.Background(),
+		&types.Alert{Header: "a", Severity: types.AlertInfo},
+		&types.Alert{Header: "b", Severity: types.AlertPanic},
+	)
+	if err == nil {
+		t.Fatal("expected error for failed severity group")
+	}
+
+	if !strings.Contains(err.Error(), `severity group "panic"`) {
+		t.Errorf("expected error to identify the panic group, got: %v", err)
+	}
+
+	if strings.Contains(err.Error(), `severity group "info"`) {
+		t.Errorf("expected info group to succeed, got: %v", err)
+	}
+
+	if meta == nil || meta.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected metadata of the failed group, got %+v", meta)
+	}
+}
+
+func TestClient_TimingCallback(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var (
+		mu      sync.Mutex
+		timings []RequestTimings
+	)
+
+	c := New(server.URL, WithTimingCallback(func(_ context.Context, rt RequestTimings) {
+		mu.Lock()
+		defer mu.Unlock()
+		timings = append(timings, rt)
+	}))
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(timings) != 2 {
+		t.Fatalf("expected 2 timing reports (ping and send), got %d", len(timings))
+	}
+
+	if timings[0].ConnReused || timings[0].Connect <= 0 {
+		t.Errorf("expected first request to dial a new connection, got %+v", timings[0])
+	}
+
+	if !timings[1].ConnReused || timings[1].Connect != 0 {
+		t.Errorf("expected second request to reuse the connection, got %+v", timings[1])
+	}
+
+	for i, rt := range timings {
+		if rt.TimeToFirstByte <= 0 {
+			t.Errorf("expected TimeToFirstByte > 0 for request %d, got %v", i, rt.TimeToFirstByte)
+		}
+	}
+}
+
+func TestClient_LastTTFB(t *testing.T) {
+	t.Parallel()
+
+	const delay = 20 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			time.Sleep(delay)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(0))
+
+	if got := c.LastTTFB(); got != 0 {
+		t.Errorf("expected zero before the first request, got %v", got)
+	}
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	meta, err := c.SendWithResponse(context.Background(), &types.Alert{Header: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.TimeToFirstByte < delay || meta.TimeToFirstByte > meta.Duration {
+		t.Errorf("expected TimeToFirstByte between %v and %v, got %v", delay, meta.Duration, meta.TimeToFirstByte)
+	}
+
+	if got := c.LastTTFB(); got != meta.TimeToFirstByte {
+		t.Errorf("expected LastTTFB=%v, got %v", meta.TimeToFirstByte, got)
+	}
+
+	server.Close()
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err == nil {
+		t.Fatal("expected the send to a closed server to fail")
+	}
+
+	if got := c.LastTTFB(); got != 0 {
+		t.Errorf("expected zero after a request without response, got %v", got)
+	}
+}
+
+func TestSend_TreatBodyErrorsAsFailure(t *testing.T) {
+	t.Parallel()
+
+	errGateway := errors.New("gateway reported errors")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/alerts" {
+			_, _ = w.Write([]byte(`{"errors":[{"message":"invalid alert"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithTreatBodyErrorsAsFailure(func(_ context.Context, body []byte) error {
+		var payload struct {
+			Errors []json.RawMessage `json:"errors"`
+		}
+		if err := json.Unmarshal(body, &payload); err == nil && len(payload.Errors) > 0 {
+			return errGateway
+		}
+		return nil
+	}))
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	meta, err := c.SendWithResponse(context.Background(), &types.Alert{Header: "test"})
+	if !errors.Is(err, errGateway) {
+		t.Fatalf("expected gateway error, got: %v", err)
+	}
+
+	if meta == nil || meta.StatusCode != http.StatusOK {
+		t.Errorf("expected metadata with status 200, got %+v", meta)
+	}
+}
+
+func TestSend_MaxSendDuration(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithMaxSendDuration(100*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	start := time.Now()
+	err := c.Send(context.Background(), &types.Alert{Header: "test"})
+
+	if !errors.Is(err, ErrSendDeadlineExceeded) {
+		t.Fatalf("expected ErrSendDeadlineExceeded, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected send to be cut off near 100ms, took %v", elapsed)
+	}
+}
+
+func TestClient_Resolve(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          []Option
+		expectedQuery string
+	}{
+		{"default marker", nil, "state=resolved"},
+		{"custom marker", []Option{WithResolveQueryParam("status", "closed")}, "status=closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var path, query string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					path = r.URL.Path
+					query = r.URL.RawQuery
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			c := New(server.URL, tt.opts...)
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			if err := c.Resolve(context.Background(), &types.Alert{Header: "test"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if path != "/alerts" {
+				t.Errorf("expected path=/alerts, got %s", path)
+			}
+
+			if query != tt.expectedQuery {
+				t.Errorf("expected query=%s, got %s", tt.expectedQuery, query)
+			}
+		})
+	}
+}
+
+func TestClient_Resolve_Validation(t *testing.T) {
+	t.Parallel()
+
+	var c *Client
+	if err := c.Resolve(context.Background(), &types.Alert{}); err == nil || err.Error() != "alert client is nil" {
+		t.Errorf("expected nil client error, got: %v", err)
+	}
+
+	c = New("http://example.com")
+	if err := c.Resolve(context.Background(), &types.Alert{}); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got: %v", err)
+	}
+}
+
+func TestSend_ErrorMessagePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		expected string
+	}{
+		{"nested path found", "data.error.message", `{"data":{"error":{"message":"nested failure"}}}`, "nested failure"},
+		{"nested path missing", "data.error.message", `{"data":{"problem":"other"}}`, `{"data":{"problem":"other"}}`},
+		{"path through non-object", "data.error.message", `{"data":"flat"}`, `{"data":"flat"}`},
+		{"non-string value", "data.error", `{"data":{"error":{"code":1}}}`, `{"data":{"error":{"code":1}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/ping" {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			c := New(server.URL, WithRetryCount(0), WithErrorMessagePath(tt.path))
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			err := c.Send(context.Background(), &types.Alert{Header: "test"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			if !strings.HasSuffix(err.Error(), ": "+tt.expected) {
+				t.Errorf("expected error to end with %q, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestClient_StatusFamilyCallback(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			w.WriteHeader(http.StatusOK)
+		case "/alerts":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	var families []int
+	c := New(server.URL, WithRetryCount(0), WithSeverityEndpoint("panic", "down"), WithStatusFamilyCallback(func(_ context.Context, family int) {
+		families = append(families, family)
+	}))
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	_ = c.Send(context.Background(), &types.Alert{Severity: types.AlertInfo}, &types.Alert{Severity: types.AlertPanic})
+
+	// No response at all must not fire the callback
+	server.Close()
+	_ = c.Send(context.Background(), &types.Alert{Header: "test"})
+
+	if fmt.Sprint(families) != "[2 4 5]" {
+		t.Errorf("expected families [2 4 5], got %v", families)
+	}
+}
+
+func TestSend_SortAlerts(t *testing.T) {
+	t.Parallel()
+
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			var payload alertsList
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			for _, alert := range payload.Alerts {
+				headers = append(headers, alert.Header)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithSortAlerts(func(a, b *types.Alert) bool { return a.Header < b.Header }))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(), &types.Alert{Header: "c"}, &types.Alert{Header: "a"}, &types.Alert{Header: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(headers, ",") != "a,b,c" {
+		t.Errorf("expected marshaled order a,b,c, got %v", headers)
+	}
+}
+
+func TestConnect_PingTolerance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		tolerance int
+		wantError bool
+	}{
+		{"tolerates flapping ping", 2, false},
+		{"too few tolerated failures", 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var pings atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if pings.Add(1) <= 2 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			c := New(server.URL, WithRetryCount(0), WithConnectPingTolerance(tt.tolerance, 10*time.Millisecond))
+			err := c.Connect(context.Background())
+
+			if tt.wantError && err == nil {
+				t.Error("expected connect to fail")
+			}
+
+			if !tt.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestConnect_PingToleranceRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := New(server.URL, WithRetryCount(0), WithConnectPingTolerance(100, time.Minute))
+
+	start := time.Now()
+	err := c.Connect(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected connect to stop at the context deadline, took %v", elapsed)
+	}
+}
+
+func TestGetBodyErrorMessage_ContentType(t *testing.T) {
+	t.Parallel()
+
+	longBody := strings.Repeat("x", 2000)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		{
+			name:        "json error field",
+			contentType: "application/json",
+			body:        `{"error":"bad alert"}`,
+			expected:    "bad alert",
+		},
+		{
+			name:        "html error page",
+			contentType: "text/html; charset=utf-8",
+			body:        "\n  <html><head><title>502 Bad Gateway</title></head>\n<body>nginx</body></html>",
+			expected:    "server returned an HTML error page (78 bytes): <html><head><title>502 Bad Gateway</title></head>",
+		},
+		{
+			name:        "long plain text truncated",
+			contentType: "text/plain",
+			body:        longBody,
+			expected:    longBody[:1024] + "... (truncated, 2000 bytes total)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusBadGateway)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			resp := makeRestyRequest(t, server.URL)
+
+			if got := getBodyErrorMessage(resp, "error"); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruncateMessage_UTF8(t *testing.T) {
+	t.Parallel()
+
+	// "é" is two bytes; cutting at byte 3 would split the second one
+	got := truncateMessage("éé", 3)
+
+	if got != "é... (truncated, 4 bytes total)" {
+		t.Errorf("unexpected truncation: %q", got)
+	}
+}
+
+func TestClient_SendToChannel(t *testing.T) {
+	t.Parallel()
+
+	var channels []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			channels = append(channels, r.Header.Get("X-Slack-Channel"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.SendToChannel(context.Background(), " incident-42 ", &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Regular sends must not carry the override
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(channels) != 2 || channels[0] != "incident-42" || channels[1] != "" {
+		t.Errorf("expected channels [incident-42 \"\"], got %q", channels)
+	}
+}
+
+func TestClient_SendToChannel_InvalidChannel(t *testing.T) {
+	t.Parallel()
+
+	c := New("http://example.com")
+
+	tests := []struct {
+		channel  string
+		expected string
+	}{
+		{"", "channel must not be empty"},
+		{"   ", "channel must not be empty"},
+		{"#general", `invalid channel "#general"`},
+	}
+
+	for _, tt := range tests {
+		err := c.SendToChannel(context.Background(), tt.channel, &types.Alert{Header: "test"})
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("channel %q: expected error %q, got %v", tt.channel, tt.expected, err)
+		}
+	}
+}
+
+func TestSend_RetryAfterOnServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && attempts.Add(1) == 1 {
+			// Far above the max wait time, so the wait is capped to it
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Without the header, the first backoff would be at most 200ms
+	c := New(server.URL, WithRetryWaitTime(100*time.Millisecond), WithRetryMaxWaitTime(300*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	start := time.Now()
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("expected the retry to wait for the capped Retry-After of 300ms, waited %v", elapsed)
+	}
+
+	if n := attempts.Load(); n != 2 {
+		t.Errorf("expected 2 attempts, got %d", n)
+	}
+}
+
+func TestConnect_Probes(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var probed []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		probed = append(probed, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithConnectProbes("OPTIONS alerts", "health"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	expected := []string{"GET /ping", "OPTIONS /alerts", "GET /health"}
+	if !slices.Equal(probed, expected) {
+		t.Errorf("expected requests %v, got %v", expected, probed)
+	}
+}
+
+func TestConnect_ProbeFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetryCount(0), WithConnectProbes("OPTIONS alerts", "health"))
+
+	err := c.Connect(context.Background())
+	if err == nil {
+		t.Fatal("expected connect to fail")
+	}
+
+	if !strings.Contains(err.Error(), "connect probe failed: OPTIONS") || !strings.Contains(err.Error(), "status code 404") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConnect_FallbackEndpoint(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, fallbackStatus int) *httptest.Server {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/ping":
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case "/alerts":
+				w.WriteHeader(fallbackStatus)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	t.Run("fallback succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, http.StatusOK)
+		logger := &recordingLogger{}
+
+		c := New(server.URL, WithRetryCount(0), WithConnectFallbackEndpoint("alerts", "OPTIONS"), WithRequestLogger(logger))
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("expected connect to succeed through the fallback, got: %v", err)
+		}
+
+		if !strings.Contains(logger.String(), "warn: ping failed, connected after a successful OPTIONS alerts instead") {
+			t.Errorf("expected a warning about the fallback, got:\n%s", logger.String())
+		}
+
+		if err := c.Send(context.Background(), &types.Alert{}); err != nil {
+			t.Errorf("expected the client to be usable, got: %v", err)
+		}
+	})
+
+	t.Run("both fail", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, http.StatusMethodNotAllowed)
+
+		c := New(server.URL, WithRetryCount(0), WithConnectFallbackEndpoint("alerts", ""))
+
+		err := c.Connect(context.Background())
+		if err == nil {
+			t.Fatal("expected connect to fail")
+		}
+
+		if !strings.Contains(err.Error(), "GET "+server.URL+"/ping failed with status code 503") ||
+			!strings.Contains(err.Error(), "connect fallback failed: HEAD "+server.URL+"/alerts failed with status code 405") {
+			t.Errorf("expected both errors, got: %v", err)
+		}
+	})
+
+	t.Run("not used when the ping succeeds", func(t *testing.T) {
+		t.Parallel()
+
+		var fallbacks atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/alerts" {
+				fallbacks.Add(1)
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(server.Close)
+
+		c := New(server.URL, WithConnectFallbackEndpoint("alerts", "HEAD"))
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+
+		if got := fallbacks.Load(); got != 0 {
+			t.Errorf("expected no fallback check, got %d", got)
+		}
+	})
+}
+
+func TestSend_AlertsEndpointResolver(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		received = map[string][]string{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var payload alertsList
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		mu.Lock()
+		for _, alert := range payload.Alerts {
+			received[r.URL.Path] = append(received[r.URL.Path], alert.Header)
+		}
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resolver := func(alerts []*types.Alert) string {
+		region, _ := alerts[0].Metadata["region"].(string)
+		if region == "" {
+			return ""
+		}
+		return region + "/alerts"
+	}
+
+	c := New(server.URL,
+		WithAlertsEndpointResolver(resolver),
+		WithSeverityEndpoint("panic", "critical-alerts"),
+	)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(),
+		&types.Alert{Header: "a", Metadata: map[string]any{"region": "eu"}},
+		&types.Alert{Header: "b", Severity: types.AlertPanic},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An empty result falls back to the default endpoint
+	if err := c.Send(context.Background(), &types.Alert{Header: "c"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := received["/eu/alerts"]; len(got) != 1 || got[0] != "a" {
+		t.Errorf("expected [a] at /eu/alerts, got %v", got)
+	}
+
+	if got := received["/critical-alerts"]; len(got) != 1 || got[0] != "b" {
+		t.Errorf("expected [b] at /critical-alerts, got %v", got)
+	}
+
+	if got := received["/alerts"]; len(got) != 1 || got[0] != "c" {
+		t.Errorf("expected [c] at /alerts, got %v", got)
+	}
+}
+
+func TestSend_AcceptCompression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		enabled          bool
+		expectedEncoding string
+	}{
+		{"enabled", true, "gzip"},
+		{"disabled", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var encoding atomic.Value
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/alerts" {
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+
+				encoding.Store(r.Header.Get("Accept-Encoding"))
+
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"error":"plain failure"}`))
+					return
+				}
+
+				var buf bytes.Buffer
+				gz := gzip.NewWriter(&buf)
+				_, _ = gz.Write([]byte(`{"error":"compressed failure"}`))
+				_ = gz.Close()
+
+				w.Header().Set("Content-Encoding", "gzip")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write(buf.Bytes())
+			}))
+			defer server.Close()
+
+			c := New(server.URL, WithAcceptCompression(tt.enabled))
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			err := c.Send(context.Background(), &types.Alert{Header: "test"})
+
+			if got := encoding.Load(); got != tt.expectedEncoding {
+				t.Errorf("expected Accept-Encoding %q, got %q", tt.expectedEncoding, got)
+			}
+
+			expected := "plain failure"
+			if tt.enabled {
+				expected = "compressed failure"
+			}
+
+			if err == nil || !strings.HasSuffix(err.Error(), ": "+expected) {
+				t.Errorf("expected error ending in %q, got %v", expected, err)
+			}
+		})
+	}
+}
+
+func TestClient_SendWithHeaders(t *testing.T) {
+	t.Parallel()
+
+	var received []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			received = append(received, r.Header.Clone())
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRequestHeader("X-Tenant", "default"), WithRequestHeader("X-Source", "client"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	headers := map[string]string{
+		" X-Tenant ":   " acme ",
+		"X-Trace-Id":   "abc123",
+		"Content-Type": "text/plain",
+		"":             "ignored",
+	}
+
+	if err := c.SendWithHeaders(context.Background(), headers, &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(received))
+	}
+
+	first := received[0]
+	if first.Get("X-Tenant") != "acme" || first.Get("X-Trace-Id") != "abc123" || first.Get("X-Source") != "client" {
+		t.Errorf("expected per-call headers merged over configured ones, got %v", first)
+	}
+
+	if first.Get("Content-Type") != "application/json" {
+		t.Errorf("expected protected Content-Type to be kept, got %q", first.Get("Content-Type"))
+	}
+
+	// The per-call headers must not leak into later calls
+	second := received[1]
+	if second.Get("X-Tenant") != "default" || second.Get("X-Trace-Id") != "" {
+		t.Errorf("expected configured headers only, got %v", second)
+	}
+}
+
+func TestSend_MaxResponseHeaderBytes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			for i := range 10 {
+				w.Header().Add("Set-Cookie", fmt.Sprintf("c%d=%s", i, strings.Repeat("x", 1024)))
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetryCount(0), WithMaxResponseHeaderBytes(4<<10))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(), &types.Alert{Header: "test"})
+	if err == nil || !strings.Contains(err.Error(), "response headers exceeded") {
+		t.Errorf("expected oversized response headers to fail, got %v", err)
+	}
+}
+
+func TestConnect_ExpectedPingBody(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		pingBody      string
+		expected      string
+		expectedError string
+	}{
+		{"matching body", `{"status":"ok"}`, `"status":"ok"`, ""},
+		{"no expectation", `{"status":"maintenance"}`, "", ""},
+		{"maintenance", `{"status":"maintenance"}`, `"status":"ok"`, `without the expected body "\"status\":\"ok\"": {"status":"maintenance"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tt.pingBody))
+			}))
+			defer server.Close()
+
+			c := New(server.URL, WithExpectedPingBody(tt.expected))
+			err := c.Connect(context.Background())
+
+			if tt.expectedError == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if tt.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedError)) {
+				t.Errorf("expected error containing %q, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestClient_SharedTransport(t *testing.T) {
+	t.Parallel()
+
+	server, conns := newConnCountingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	transport := &http.Transport{}
+	defer transport.CloseIdleConnections()
+
+	first := New(server.URL, WithSharedTransport(transport))
+	second := New(server.URL, WithSharedTransport(transport), WithAlertsEndpoint("other-alerts"))
+
+	for _, c := range []*Client{first, second} {
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+
+		if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Closing one client must leave the shared pool to its owner
+	first.Close()
+
+	if err := second.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := conns.Load(); n != 1 {
+		t.Errorf("expected both clients to share a single connection, got %d connections", n)
+	}
+}
+
+func TestSend_LargeBatchWarning(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+
+	c := New(server.URL, WithLargeBatchWarning(2), WithRequestLogger(logger))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	alert := &types.Alert{Header: "test"}
+
+	if err := c.Send(context.Background(), alert, alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if logs := logger.String(); logs != "" {
+		t.Errorf("expected no warning at the threshold, got %q", logs)
+	}
+
+	if err := c.Send(context.Background(), alert, alert, alert); err != nil {
+		t.Fatalf("expected the large batch to be sent, got %v", err)
+	}
+
+	if logs := logger.String(); logs != "warn: sending 3 alerts in one batch, above the warning threshold of 2" {
+		t.Errorf("unexpected warning %q", logs)
+	}
+}
+
+func TestSend_DefaultRequestContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(0), WithDefaultRequestContext(50*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	t.Run("no deadline", func(t *testing.T) {
+		t.Parallel()
+
+		start := time.Now()
+
+		err := c.Send(context.Background(), &types.Alert{Header: "test"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected the default timeout to apply, took %v", elapsed)
+		}
+	})
+
+	t.Run("caller deadline respected", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		if err := c.Send(ctx, &types.Alert{Header: "test"}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+
+		if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+			t.Errorf("expected the caller's longer deadline to win, took %v", elapsed)
+		}
+	})
+}
+
+func TestSend_IndentedJSON(t *testing.T) {
+	t.Parallel()
+
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			body, _ = io.ReadAll(r.Body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithIndentedJSON(true))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(string(body), "{\n  \"alerts\": [\n    {\n") {
+		t.Errorf("expected an indented payload, got %s", body)
+	}
+
+	var payload alertsList
+	if err := json.Unmarshal(body, &payload); err != nil || len(payload.Alerts) != 1 || payload.Alerts[0].Header != "test" {
+		t.Errorf("expected a valid envelope, got %s (%v)", body, err)
+	}
+}
+
+func TestConnect_DialNetwork(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("tcp4 reaches an IPv4 server", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithDialNetwork("tcp4"))
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+	})
+
+	t.Run("tcp6 refuses an IPv4 address", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithDialNetwork("tcp6"), WithRetryCount(0))
+		if err := c.Connect(context.Background()); err == nil {
+			t.Fatal("expected IPv6-only dialing of an IPv4 address to fail")
+		}
+	})
+}
+
+func TestNewDialContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected bool
+	}{
+		{"default", nil, false},
+		{"tcp dial network", []Option{WithDialNetwork("tcp")}, false},
+		{"tcp4 dial network", []Option{WithDialNetwork("tcp4")}, true},
+		{"pool stats", []Option{WithPoolStats(true)}, true},
+		{"connection lifetime", []Option{WithConnMaxLifetime(time.Minute)}, true},
+		{"connection validation", []Option{WithValidateConnOnUse(true)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := New("http://localhost", tt.opts...).newDialContext() != nil; got != tt.expected {
+				t.Errorf("expected a custom dialer=%v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSend_DrainContext(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+
+			if r.URL.Query().Get("state") == "" {
+				close(started)
+				<-release
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	drainCtx, drain := context.WithCancel(context.Background())
+
+	c := New(server.URL, WithDrainContext(drainCtx))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	inFlight := make(chan error, 1)
+
+	go func() {
+		inFlight <- c.Send(context.Background(), &types.Alert{})
+	}()
+
+	<-started
+	drain()
+
+	if err := c.Resolve(context.Background(), &types.Alert{}); !errors.Is(err, ErrDraining) {
+		t.Errorf("expected ErrDraining for a new send, got %v", err)
+	}
+
+	close(release)
+
+	if err := <-inFlight; err != nil {
+		t.Errorf("expected the in-flight send to complete, got %v", err)
+	}
+}
+
+func TestTrySend(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && attempts.Add(1) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	drainCtx, drain := context.WithCancel(context.Background())
+
+	c := New(server.URL, WithDrainContext(drainCtx), WithRetryCount(0))
+
+	if sent, err := c.TrySend(context.Background(), &types.Alert{}); sent || err != nil {
+		t.Errorf("expected a skipped send before connect, got sent=%v err=%v", sent, err)
+	}
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if sent, err := c.TrySend(context.Background(), &types.Alert{}); !sent || err != nil {
+		t.Errorf("expected a successful send, got sent=%v err=%v", sent, err)
+	}
+
+	if sent, err := c.TrySend(context.Background(), &types.Alert{}); sent || err == nil {
+		t.Errorf("expected a failed send, got sent=%v err=%v", sent, err)
+	}
+
+	drain()
+
+	if sent, err := c.TrySend(context.Background(), &types.Alert{}); sent || err != nil {
+		t.Errorf("expected a skipped send while draining, got sent=%v err=%v", sent, err)
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestTrySend_FailedConnect(t *testing.T) {
+	t.Parallel()
+
+	var nilClient *Client
+
+	if _, err := nilClient.TrySend(context.Background(), &types.Alert{}); err == nil {
+		t.Error("expected error for nil client")
+	}
+
+	c := New("")
+	_ = c.Connect(context.Background())
+
+	if sent, err := c.TrySend(context.Background(), &types.Alert{}); sent || err != nil {
+		t.Errorf("expected a skipped send after a failed connect, got sent=%v err=%v", sent, err)
+	}
+}
+
+func TestSend_AlertValidator(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			attempts.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	errTooLong := errors.New("text longer than 10 characters")
+
+	c := New(server.URL, WithAlertValidator(func(alert *types.Alert) error {
+		if len(alert.Text) > 10 {
+			return errTooLong
+		}
+
+		return nil
+	}))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(), &types.Alert{Text: "short"}, &types.Alert{Text: "far too long"})
+	if !errors.Is(err, errTooLong) || !strings.HasPrefix(err.Error(), "alert at index 1: ") {
+		t.Errorf("expected the validator error for alert 1, got: %v", err)
+	}
+
+	if got := attempts.Load(); got != 0 {
+		t.Errorf("expected nothing to be sent, got %d requests", got)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "short"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected a valid alert to be sent, got %d requests", got)
+	}
+}
+
+func TestSend_DeliveryFailureSink(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	var (
+		calls   int
+		failed  []*types.Alert
+		sinkErr error
+	)
+
+	c := New(server.URL,
+		WithRetryCount(1),
+		WithRetryWaitTime(100*time.Millisecond),
+		WithRetryMaxWaitTime(100*time.Millisecond),
+		WithDeliveryFailureSink(func(_ context.Context, alerts []*types.Alert, err error) {
+			calls++
+			failed = alerts
+			sinkErr = err
+		}),
+	)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(), &types.Alert{Text: "first"}, &types.Alert{Text: "second"})
+	if err == nil {
+		t.Fatal("expected the send to fail")
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected the sink to be called once, got %d calls", calls)
+	}
+
+	if len(failed) != 2 || failed[0].Text != "first" || failed[1].Text != "second" {
+		t.Errorf("expected both alerts to be passed, got %+v", failed)
+	}
+
+	if sinkErr == nil || sinkErr.Error() != err.Error() {
+		t.Errorf("expected the send error %v, got %v", err, sinkErr)
+	}
+}
+
+func TestSend_DeliveryFailureSink_SeverityGroups(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/critical-alerts" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	var failed []*types.Alert
+
+	c := New(server.URL,
+		WithRetryCount(0),
+		WithSeverityEndpoint("panic", "critical-alerts"),
+		WithDeliveryFailureSink(func(_ context.Context, alerts []*types.Alert, _ error) { failed = alerts }),
+	)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(),
+		&types.Alert{Header: "a", Severity: types.AlertInfo},
+		&types.Alert{Header: "b", Severity: types.AlertPanic},
+	)
+	if err == nil {
+		t.Fatal("expected error for failed severity group")
+	}
+
+	if len(failed) != 1 || failed[0].Header != "b" {
+		t.Errorf("expected only the alert of the failed group, got %+v", failed)
+	}
+}
+
+func TestSend_DeliveryFailureSink_NotCalledBeforeDelivery(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	var calls atomic.Int32
+
+	errRejected := errors.New("rejected")
+
+	c := New(server.URL,
+		WithDeliveryFailureSink(func(context.Context, []*types.Alert, error) { calls.Add(1) }),
+		WithMiddleware(func(next SendFunc) SendFunc {
+			return func(ctx context.Context, alerts ...*types.Alert) error {
+				if alerts[0].Text == "reject" {
+					return errRejected
+				}
+
+				return next(ctx, alerts...)
+			}
+		}),
+	)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Send(context.Background(), nil); err == nil {
+		t.Fatal("expected a validation error")
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "reject"}); !errors.Is(err, errRejected) {
+		t.Fatalf("expected the middleware error, got %v", err)
+	}
+
+	if got := calls.Load(); got != 0 {
+		t.Errorf("expected no sink calls, got %d", got)
+	}
+}
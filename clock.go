@@ -0,0 +1,17 @@
+package client
+
+import "time"
+
+// Clock provides the current time to the client. The default uses the
+// system clock; supply a fake with [WithClock] to make time-dependent
+// behaviour deterministic in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default [Clock], backed by [time.Now].
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a [Clock] that only moves when advanced by the test.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func TestSystemClock(t *testing.T) {
+	t.Parallel()
+
+	before := time.Now()
+	now := systemClock{}.Now()
+	after := time.Now()
+
+	if now.Before(before) || now.After(after) {
+		t.Errorf("expected system clock time between %v and %v, got %v", before, after, now)
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// ClassifyConnectionError maps common low-level network errors to a short,
+// actionable explanation, e.g. "the alerts server is not accepting
+// connections - is it running?" for a refused connection. It returns an empty
+// string for nil, for cancelled contexts and for errors it does not
+// recognise.
+//
+// Request errors returned by the client already include this explanation next
+// to the original error; the function is exported so that callers can reuse
+// it for their own diagnostics.
+func ClassifyConnectionError(err error) string {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return ""
+	}
+
+	if errors.Is(err, ErrCertificatePinMismatch) {
+		return "the server certificate does not match any configured pin - check the pins or the server certificate"
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsNotFound {
+			return "the alerts server host name could not be resolved - check the base URL"
+		}
+
+		return "the DNS lookup for the alerts server failed - check the resolver configuration"
+	}
+
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+	)
+
+	if errors.As(err, &verifyErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return "the server TLS certificate could not be verified - check the CA configuration and the base URL host name"
+	}
+
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "the alerts server is not accepting connections - is it running?"
+	case errors.Is(err, syscall.ECONNRESET):
+		return "the connection was reset by the alerts server or a proxy in between"
+	case errors.Is(err, syscall.ENETUNREACH), errors.Is(err, syscall.EHOSTUNREACH):
+		return "the alerts server is unreachable from this host - check routing and firewall rules"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "the alerts server did not respond in time - check connectivity or raise the timeout"
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "the alerts server did not respond in time - check connectivity or raise the timeout"
+	}
+
+	return ""
+}
+
+// requestError wraps a transport-level error for method and path, adding the
+// explanation from [ClassifyConnectionError] when there is one.
+func requestError(method, path string, err error) error {
+	if hint := ClassifyConnectionError(err); hint != "" {
+		return fmt.Errorf("%s %s failed (%s): %w", method, path, hint, err)
+	}
+
+	return fmt.Errorf("%s %s failed: %w", method, path, err)
+}
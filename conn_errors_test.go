@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestClassifyConnectionError(t *testing.T) {
+	t.Parallel()
+
+	opErr := func(errno syscall.Errno) error {
+		return &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", errno)}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "unknown", err: errors.New("boom"), want: ""},
+		{name: "canceled", err: fmt.Errorf("wrapped: %w", context.Canceled), want: ""},
+		{name: "refused", err: opErr(syscall.ECONNREFUSED), want: "not accepting connections"},
+		{name: "reset", err: opErr(syscall.ECONNRESET), want: "connection was reset"},
+		{name: "unreachable", err: opErr(syscall.EHOSTUNREACH), want: "unreachable from this host"},
+		{name: "host not found", err: &net.DNSError{Err: "no such host", Name: "alerts.invalid", IsNotFound: true}, want: "could not be resolved"},
+		{name: "dns failure", err: &net.DNSError{Err: "server misbehaving", Name: "alerts.example"}, want: "DNS lookup"},
+		{name: "deadline", err: fmt.Errorf("wrapped: %w", context.DeadlineExceeded), want: "did not respond in time"},
+		{name: "unknown authority", err: x509.UnknownAuthorityError{}, want: "could not be verified"},
+		{name: "pin mismatch", err: fmt.Errorf("tls: %w", ErrCertificatePinMismatch), want: "does not match any configured pin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ClassifyConnectionError(tt.err)
+
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("expected no classification, got %q", got)
+				}
+
+				return
+			}
+
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("expected classification containing %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConnect_ConnectionErrorClassified(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	c := New("http://"+addr, WithRetryCount(0))
+
+	err = c.Connect(context.Background())
+	if err == nil {
+		t.Fatal("expected error when the server is down")
+	}
+
+	if !strings.Contains(err.Error(), "is it running?") {
+		t.Errorf("expected a classified error, got: %v", err)
+	}
+
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Errorf("expected the original error to be wrapped, got: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// lifetimeDialContext wraps dial so that every connection it opens expires
+// after lifetime. An expired connection is closed as soon as no request is
+// using it, which makes the transport dial a fresh one for the next request.
+func lifetimeDialContext(dial dialFunc, lifetime time.Duration) dialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return newLifetimeConn(conn, lifetime), nil
+	}
+}
+
+// lifetimeConn is a net.Conn that closes itself once it has expired and is
+// no longer used by any request.
+type lifetimeConn struct {
+	net.Conn
+
+	timer   *time.Timer
+	mu      sync.Mutex
+	inUse   int
+	expired bool
+}
+
+func newLifetimeConn(conn net.Conn, lifetime time.Duration) *lifetimeConn {
+	c := &lifetimeConn{Conn: conn}
+	c.timer = time.AfterFunc(lifetime, c.expire)
+
+	return c
+}
+
+func (c *lifetimeConn) expire() {
+	c.mu.Lock()
+	c.expired = true
+	idle := c.inUse == 0
+	c.mu.Unlock()
+
+	if idle {
+		_ = c.Close()
+	}
+}
+
+func (c *lifetimeConn) acquire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inUse++
+}
+
+func (c *lifetimeConn) release() {
+	c.mu.Lock()
+	c.inUse--
+	done := c.expired && c.inUse == 0
+	c.mu.Unlock()
+
+	if done {
+		_ = c.Close()
+	}
+}
+
+func (c *lifetimeConn) Close() error {
+	c.timer.Stop()
+	return c.Conn.Close()
+}
+
+// lifetimeUsage tracks the connections used by a single request, so that
+// connections which expired while the request was in flight are closed once
+// it completes.
+type lifetimeUsage struct {
+	mu    sync.Mutex
+	conns []*lifetimeConn
+}
+
+// withTrace returns a copy of ctx carrying the usage hooks.
+func (u *lifetimeUsage) withTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: u.gotConn,
+	})
+}
+
+func (u *lifetimeUsage) gotConn(info httptrace.GotConnInfo) {
+	lc, ok := unwrapLifetimeConn(info.Conn)
+	if !ok {
+		return
+	}
+
+	lc.acquire()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.conns = append(u.conns, lc)
+}
+
+// unwrapLifetimeConn finds the [lifetimeConn] under conn. TLS connections
+// and the [validatingConn] of [WithValidateConnOnUse] wrap it, and expose it
+// through their NetConn method.
+func unwrapLifetimeConn(conn net.Conn) (*lifetimeConn, bool) {
+	for {
+		if lc, ok := conn.(*lifetimeConn); ok {
+			return lc, true
+		}
+
+		wrapper, ok := conn.(interface{ NetConn() net.Conn })
+		if !ok {
+			return nil, false
+		}
+
+		conn = wrapper.NetConn()
+	}
+}
+
+// release hands the connections used by the request back. It is called once
+// the request has completed.
+func (u *lifetimeUsage) release() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, lc := range u.conns {
+		lc.release()
+	}
+
+	u.conns = nil
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newConnCountingServer returns a test server that counts the connections
+// accepted from clients.
+func newConnCountingServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	var conns atomic.Int64
+
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return server, &conns
+}
+
+func TestClient_ConnMaxLifetime_Disabled(t *testing.T) {
+	t.Parallel()
+
+	server, conns := newConnCountingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	for range 3 {
+		if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if n := conns.Load(); n != 1 {
+		t.Errorf("expected a single reused connection, got %d", n)
+	}
+}
+
+func TestClient_ConnMaxLifetime(t *testing.T) {
+	t.Parallel()
+
+	server, conns := newConnCountingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := New(server.URL, WithConnMaxLifetime(50*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := conns.Load(); n != 1 {
+		t.Fatalf("expected the connection to be reused within its lifetime, got %d connections", n)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := conns.Load(); n != 2 {
+		t.Errorf("expected the expired connection to be replaced, got %d connections", n)
+	}
+}
+
+func TestClient_ConnMaxLifetime_ExpiresWhileInUse(t *testing.T) {
+	t.Parallel()
+
+	server, conns := newConnCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		if r.URL.Path == "/alerts" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := New(server.URL, WithConnMaxLifetime(50*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	// The connection expires mid-request, which must not fail the request
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := conns.Load(); n != 2 {
+		t.Errorf("expected the connection to be replaced after the request, got %d connections", n)
+	}
+}
+
+func TestClient_ConnMaxLifetime_WithValidateConnOnUse(t *testing.T) {
+	t.Parallel()
+
+	server, conns := newConnCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		if r.URL.Path == "/alerts" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	c := New(server.URL, WithConnMaxLifetime(50*time.Millisecond), WithValidateConnOnUse(true), WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	// The validating wrapper must not hide the connection from the lifetime
+	// tracking, or the connection is closed under the request
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := conns.Load(); n != 2 {
+		t.Errorf("expected the connection to be replaced after the request, got %d connections", n)
+	}
+}
+
+func TestUnwrapLifetimeConn(t *testing.T) {
+	t.Parallel()
+
+	client, server := net.Pipe()
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+
+	lc := newLifetimeConn(client, time.Hour)
+	wrapped := &validatingConn{Conn: lc, clock: systemClock{}}
+
+	if got, ok := unwrapLifetimeConn(wrapped); !ok || got != lc {
+		t.Errorf("expected the lifetime connection under the validating wrapper, got %v", got)
+	}
+
+	if _, ok := unwrapLifetimeConn(server); ok {
+		t.Error("expected no lifetime connection under a plain connection")
+	}
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// staleConnIdleTime is how long a pooled connection may sit idle before
+// [WithValidateConnOnUse] replaces it with a fresh one on its next use.
+const staleConnIdleTime = 10 * time.Second
+
+// errStaleConn is returned by the first write on a connection that was idle
+// for too long. As nothing has been written yet, the transport transparently
+// retries the request on a new connection.
+var errStaleConn = errors.New("pooled connection idle for too long")
+
+// validatingDialContext wraps dial so that every connection it opens refuses
+// to be reused after being idle for longer than staleConnIdleTime, see
+// [WithValidateConnOnUse].
+func validatingDialContext(dial dialFunc, clock Clock) dialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		return &validatingConn{Conn: conn, clock: clock, lastUsed: clock.Now()}, nil
+	}
+}
+
+// validatingConn is a net.Conn that closes itself instead of writing when it
+// has not been used for longer than staleConnIdleTime.
+type validatingConn struct {
+	net.Conn
+
+	clock    Clock
+	mu       sync.Mutex
+	lastUsed time.Time
+}
+
+// NetConn returns the wrapped connection, like [tls.Conn.NetConn].
+func (c *validatingConn) NetConn() net.Conn {
+	return c.Conn
+}
+
+func (c *validatingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.touch()
+	}
+
+	return n, err
+}
+
+func (c *validatingConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	stale := c.clock.Now().Sub(c.lastUsed) > staleConnIdleTime
+	c.mu.Unlock()
+
+	if stale {
+		_ = c.Conn.Close()
+		return 0, errStaleConn
+	}
+
+	n, err := c.Conn.Write(b)
+	c.touch()
+
+	return n, err
+}
+
+func (c *validatingConn) touch() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastUsed = c.clock.Now()
+}
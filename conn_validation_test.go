@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestClient_ValidateConnOnUse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		idle          time.Duration
+		expectedConns int64
+	}{
+		{"recently used connection is reused", time.Second, 1},
+		{"stale connection is replaced", staleConnIdleTime + time.Second, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, conns := newConnCountingServer(t, func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.Copy(io.Discard, r.Body)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			clock := newFakeClock(time.Now())
+
+			c := New(server.URL, WithValidateConnOnUse(true), WithClock(clock), WithRetryCount(0))
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			clock.Advance(tt.idle)
+
+			if err := c.Send(context.Background(), &types.Alert{}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := conns.Load(); got != tt.expectedConns {
+				t.Errorf("expected %d connection(s), got %d", tt.expectedConns, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// waitConnectJitter waits for a random duration below the maximum set with
+// [WithConnectJitter], drawn from the client's random source. It returns
+// the context error if ctx is done first.
+func (c *Client) waitConnectJitter(ctx context.Context) error {
+	if c.options.connectJitter <= 0 {
+		return nil
+	}
+
+	wait := time.Duration(rand.New(c.options.randSource).Int64N(int64(c.options.connectJitter))) //nolint:gosec // jitter does not need a secure source
+
+	c.options.requestLogger.Debugf("waiting %v before the connect ping", wait)
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConnect_Jitter(t *testing.T) {
+	t.Parallel()
+
+	var pings atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			pings.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	const maxWait = 50 * time.Millisecond
+
+	expected := time.Duration(rand.New(rand.NewPCG(1, 2)).Int64N(int64(maxWait))) //nolint:gosec // test
+
+	c := New(server.URL, WithConnectJitter(maxWait), WithRandSource(rand.NewPCG(1, 2)))
+
+	start := time.Now()
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < expected {
+		t.Errorf("expected Connect to wait at least %v, took %v", expected, elapsed)
+	}
+
+	if got := pings.Load(); got != 1 {
+		t.Errorf("expected 1 ping, got %d", got)
+	}
+}
+
+func TestConnect_JitterRespectsContext(t *testing.T) {
+	t.Parallel()
+
+	var pings atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		pings.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	c := New(server.URL, WithConnectJitter(time.Hour))
+
+	err := c.Connect(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+
+	if got := pings.Load(); got != 0 {
+		t.Errorf("expected no ping, got %d", got)
+	}
+}
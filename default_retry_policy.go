@@ -26,8 +26,10 @@ var permanentConnErrors = []syscall.Errno{ //nolint:gochecknoglobals
 // DefaultRetryPolicy is the default retry condition used by [Client]. It
 // retries on HTTP 429 (rate limit) and 5xx server errors, and on transient
 // connection errors. It does not retry on context cancellation, deadline
-// exceeded, DNS resolution failures, or permanent connection failures
-// (connection refused, network/host unreachable, permission denied).
+// exceeded, DNS resolution failures, certificate pin mismatches, or permanent
+// connection failures (connection refused, network/host unreachable,
+// permission denied). Timed-out sends are retried with
+// [WithResponseTimeoutRetry].
 //
 // Supply a custom function via [WithRetryPolicy] to override this behaviour.
 func DefaultRetryPolicy(r *resty.Response, err error) bool {
@@ -37,6 +39,11 @@ func DefaultRetryPolicy(r *resty.Response, err error) bool {
 			return false
 		}
 
+		// Don't retry when the server certificate does not match a pin
+		if errors.Is(err, ErrCertificatePinMismatch) {
+			return false
+		}
+
 		// Don't retry on DNS resolution errors
 		var dnsErr *net.DNSError
 		if errors.As(err, &dnsErr) {
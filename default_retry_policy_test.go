@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"syscall"
 	"testing"
 
@@ -46,6 +48,16 @@ func TestDefaultRetryPolicy_DNSError(t *testing.T) {
 	}
 }
 
+func TestDefaultRetryPolicy_CertificatePinMismatch(t *testing.T) {
+	t.Parallel()
+
+	err := &url.Error{Op: "Get", URL: "https://example.com", Err: fmt.Errorf("%w for example.com", ErrCertificatePinMismatch)}
+
+	if DefaultRetryPolicy(nil, err) {
+		t.Error("expected false for certificate pin mismatch")
+	}
+}
+
 func TestDefaultRetryPolicy_PermanentConnErrors(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"maps"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"strconv"
+)
+
+const (
+	// modulePath is the module path of this package, used to look up the
+	// client version in the build info.
+	modulePath = "github.com/slackmgr/go-client"
+
+	// envelopeMetaKeySize is the size of the meta key in the request
+	// envelope, i.e. len(`,"meta":`).
+	envelopeMetaKeySize = len(`,"meta":`)
+)
+
+// envelopeMetadata returns the meta object of the request envelope: the
+// detected hostname, process ID and client version, overlaid by configured,
+// so that the caller's values win.
+func envelopeMetadata(configured map[string]string) map[string]string {
+	meta := map[string]string{
+		"pid":            strconv.Itoa(os.Getpid()),
+		"client_version": clientVersion(),
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		meta["hostname"] = hostname
+	}
+
+	maps.Copy(meta, configured)
+
+	return meta
+}
+
+// clientVersion returns the version of this module in the running binary, or
+// "unknown" when it cannot be determined.
+func clientVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+
+			return dep.Version
+		}
+	}
+
+	return "unknown"
+}
+
+// envelopeSize is the size of the request envelope around the alerts,
+// including the meta object when [WithEnvelopeMetadata] is used.
+func (c *Client) envelopeSize() int {
+	if c.envelopeMeta == nil {
+		return batchEnvelopeSize
+	}
+
+	return batchEnvelopeSize + envelopeMetaKeySize + estimateJSONSize(reflect.ValueOf(c.envelopeMeta))
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestEnvelopeMetadata(t *testing.T) {
+	t.Parallel()
+
+	c, recorder := newRecordingClient(t, nil, WithEnvelopeMetadata(map[string]string{"team": "payments", "pid": "override"}))
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload alertsList
+	if err := json.Unmarshal(recorder.all()[0].body, &payload); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+
+	if len(payload.Alerts) != 1 {
+		t.Errorf("expected 1 alert, got %d", len(payload.Alerts))
+	}
+
+	hostname, _ := os.Hostname()
+
+	if payload.Meta["team"] != "payments" || payload.Meta["hostname"] != hostname || payload.Meta["client_version"] == "" {
+		t.Errorf("unexpected envelope metadata: %v", payload.Meta)
+	}
+
+	if payload.Meta["pid"] != "override" {
+		t.Errorf("expected configured values to take precedence, got pid=%q", payload.Meta["pid"])
+	}
+}
+
+func TestEnvelopeMetadata_AbsentByDefault(t *testing.T) {
+	t.Parallel()
+
+	c, recorder := newRecordingClient(t, nil)
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(recorder.all()[0].body, &payload); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+
+	if _, ok := payload["meta"]; ok || len(payload) != 1 {
+		t.Errorf("expected only the alerts in the envelope, got %v", payload)
+	}
+}
+
+func TestEnvelopeMetadata_Detected(t *testing.T) {
+	t.Parallel()
+
+	meta := envelopeMetadata(nil)
+
+	if meta["pid"] != strconv.Itoa(os.Getpid()) {
+		t.Errorf("expected the process ID, got %q", meta["pid"])
+	}
+
+	if meta["client_version"] != clientVersion() {
+		t.Errorf("expected the client version, got %q", meta["client_version"])
+	}
+}
+
+func TestEnvelopeMetadata_BatchBySizeCountsMeta(t *testing.T) {
+	t.Parallel()
+
+	c, recorder := newRecordingClient(t, nil, WithEnvelopeMetadata(map[string]string{"padding": strings.Repeat("x", 400)}))
+
+	alerts := []*types.Alert{{Text: "a"}, {Text: "b"}, {Text: "c"}}
+	limit := EstimateSendSize(alerts...) + 100
+
+	if err := c.SendBatchBySize(context.Background(), limit, alerts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	requests := recorder.all()
+
+	for _, r := range requests {
+		if len(r.body) > limit {
+			t.Errorf("request body of %d bytes exceeds the limit of %d", len(r.body), limit)
+		}
+	}
+
+	if len(requests) < 2 {
+		t.Errorf("expected the metadata to force more than one request, got %d", len(requests))
+	}
+}
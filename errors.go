@@ -0,0 +1,37 @@
+package client
+
+import "errors"
+
+// ErrSendDeadlineExceeded is returned when a send does not complete within
+// the duration configured with [WithMaxSendDuration].
+var ErrSendDeadlineExceeded = errors.New("send deadline exceeded")
+
+// ErrPayloadValidation is returned when an outgoing payload is rejected by the
+// validator configured with [WithPayloadValidator].
+var ErrPayloadValidation = errors.New("payload validation failed")
+
+// ErrCertificatePinMismatch is returned when none of the certificates
+// presented by the server match a fingerprint pinned with
+// [WithCertificatePin].
+var ErrCertificatePinMismatch = errors.New("server certificate does not match any pinned fingerprint")
+
+// ErrAlertTooLarge is returned by [Client.SendBatchBySize] when a single alert
+// does not fit in a batch of the requested size.
+var ErrAlertTooLarge = errors.New("alert exceeds the maximum batch size")
+
+// ErrResponseVersionMismatch is returned when a response carries an
+// X-API-Version header other than the one set with
+// [WithExpectedResponseVersion] and [WithStrictVersioning] is enabled.
+var ErrResponseVersionMismatch = errors.New("unexpected response API version")
+
+// ErrDraining is returned by sends made after the context set with
+// [WithDrainContext] is done.
+var ErrDraining = errors.New("client is draining")
+
+// ErrNoAlertsAfterFilter is returned by sends whose alerts are all below the
+// severity set with [WithMinSeverity]. Nothing is sent.
+var ErrNoAlertsAfterFilter = errors.New("no alerts left after filtering")
+
+// ErrResponseChecksumMismatch is returned when [WithVerifyResponseChecksum]
+// is enabled and a response body does not match its X-Body-SHA256 header.
+var ErrResponseChecksumMismatch = errors.New("response body checksum mismatch")
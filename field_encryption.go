@@ -0,0 +1,114 @@
+package client
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// encryptedFieldsHeader lists the alert fields encrypted with
+// [WithFieldEncryption].
+const encryptedFieldsHeader = "X-Encrypted-Fields"
+
+// fieldEncrypter encrypts selected string fields of alerts with AES-GCM.
+type fieldEncrypter struct {
+	aead   cipher.AEAD
+	fields []int  // indexes of the encrypted fields in types.Alert
+	names  string // comma-separated JSON names, for the header
+}
+
+// encryptableFields maps the JSON name of each plain string field of
+// types.Alert to its field index.
+func encryptableFields() map[string]int {
+	fields := make(map[string]int)
+	alertType := reflect.TypeFor[types.Alert]()
+
+	for i := range alertType.NumField() {
+		field := alertType.Field(i)
+		if field.Type != reflect.TypeFor[string]() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = i
+		}
+	}
+
+	return fields
+}
+
+// newFieldEncrypter returns an encrypter for the named JSON fields using key,
+// which must be 16, 24 or 32 bytes long to select AES-128, AES-192 or
+// AES-256.
+func newFieldEncrypter(key []byte, names []string) (*fieldEncrypter, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	known := encryptableFields()
+	e := &fieldEncrypter{aead: aead, names: strings.Join(names, ",")}
+
+	for _, name := range names {
+		index, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("alert has no string field %q", name)
+		}
+
+		e.fields = append(e.fields, index)
+	}
+
+	return e, nil
+}
+
+// encrypt returns clones of alerts with the selected fields replaced by the
+// base64 encoding of nonce||ciphertext. Empty fields are left empty.
+func (e *fieldEncrypter) encrypt(alerts []*types.Alert) ([]*types.Alert, error) {
+	encrypted := make([]*types.Alert, len(alerts))
+
+	for i, alert := range alerts {
+		clone := cloneAlert(alert)
+		value := reflect.ValueOf(clone).Elem()
+
+		for _, index := range e.fields {
+			field := value.Field(index)
+			if field.String() == "" {
+				continue
+			}
+
+			ciphertext, err := e.seal(field.String())
+			if err != nil {
+				return nil, err
+			}
+
+			field.SetString(ciphertext)
+		}
+
+		encrypted[i] = clone
+	}
+
+	return encrypted, nil
+}
+
+func (e *fieldEncrypter) seal(plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func decryptField(t *testing.T, key []byte, value string) string {
+	t.Helper()
+
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("invalid base64 %q: %v", value, err)
+	}
+
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCM(block)
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("failed to decrypt %q: %v", value, err)
+	}
+
+	return string(plaintext)
+}
+
+func TestSend_FieldEncryption(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{7}, 32)
+
+	var (
+		header  string
+		payload alertsList
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			header = r.Header.Get("X-Encrypted-Fields")
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithFieldEncryption(key, "text", "author", "text"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	original := &types.Alert{Header: "disk full", Text: "user jane@example.com", Footer: "plain"}
+
+	if err := c.Send(context.Background(), original); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if header != "text,author" {
+		t.Errorf("expected X-Encrypted-Fields=text,author, got %q", header)
+	}
+
+	sent := payload.Alerts[0]
+
+	if got := decryptField(t, key, sent.Text); got != "user jane@example.com" {
+		t.Errorf("expected text to decrypt to the original, got %q", got)
+	}
+
+	if sent.Author != "" {
+		t.Errorf("expected the empty author to stay empty, got %q", sent.Author)
+	}
+
+	if sent.Header != "disk full" || sent.Footer != "plain" {
+		t.Errorf("expected other fields to stay plaintext, got header=%q footer=%q", sent.Header, sent.Footer)
+	}
+
+	if original.Text != "user jane@example.com" {
+		t.Errorf("expected the caller's alert to be untouched, got %q", original.Text)
+	}
+}
+
+func TestFieldEncrypter_RandomNonce(t *testing.T) {
+	t.Parallel()
+
+	e, err := newFieldEncrypter(bytes.Repeat([]byte{1}, 16), []string{"text"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	alert := &types.Alert{Text: "secret"}
+
+	encrypted, err := e.encrypt([]*types.Alert{alert, alert})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if encrypted[0].Text == encrypted[1].Text {
+		t.Error("expected equal plaintexts to encrypt differently")
+	}
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// inFlightRequests tracks the requests in flight so that
+// [Client.CloseWithGrace] can wait for them and cancel the stragglers.
+type inFlightRequests struct {
+	mu      sync.Mutex
+	nextID  uint64
+	cancels map[uint64]context.CancelFunc
+	drained chan struct{}
+}
+
+// start registers a request and returns its context, which is cancelled by
+// cancelAll, and the function to call once the request has completed.
+func (r *inFlightRequests) start(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancels == nil {
+		r.cancels = make(map[uint64]context.CancelFunc)
+	}
+
+	id := r.nextID
+	r.nextID++
+	r.cancels[id] = cancel
+
+	return ctx, func() {
+		cancel()
+		r.finish(id)
+	}
+}
+
+func (r *inFlightRequests) finish(id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.cancels, id)
+
+	if len(r.cancels) == 0 && r.drained != nil {
+		close(r.drained)
+		r.drained = nil
+	}
+}
+
+// count returns the number of requests in flight.
+func (r *inFlightRequests) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.cancels)
+}
+
+// wait blocks until no request is in flight or timeout elapses, and reports
+// whether all requests completed.
+func (r *inFlightRequests) wait(timeout time.Duration) bool {
+	r.mu.Lock()
+
+	if len(r.cancels) == 0 {
+		r.mu.Unlock()
+		return true
+	}
+
+	if r.drained == nil {
+		r.drained = make(chan struct{})
+	}
+
+	drained := r.drained
+	r.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-drained:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// cancelAll cancels every request in flight.
+func (r *inFlightRequests) cancelAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, cancel := range r.cancels {
+		cancel()
+	}
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// byteLimiter bounds the total size of the request bodies in flight, see
+// [WithMaxInFlightBytes]. Waiting requests are admitted in arrival order, so
+// a large body is not starved by a stream of small ones.
+type byteLimiter struct {
+	mu       sync.Mutex
+	capacity int64
+	used     int64
+	waiters  list.List // of *byteWaiter
+}
+
+type byteWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+func newByteLimiter(capacity int64) *byteLimiter {
+	return &byteLimiter{capacity: capacity}
+}
+
+// acquire blocks until n bytes are available or ctx is done, and returns the
+// number of bytes to pass to release. A body larger than the capacity is
+// admitted once nothing else is in flight.
+func (l *byteLimiter) acquire(ctx context.Context, n int64) (int64, error) {
+	n = min(n, l.capacity)
+
+	l.mu.Lock()
+
+	if l.used+n <= l.capacity && l.waiters.Len() == 0 {
+		l.used += n
+		l.mu.Unlock()
+
+		return n, nil
+	}
+
+	w := &byteWaiter{n: n, ready: make(chan struct{})}
+	elem := l.waiters.PushBack(w)
+
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return n, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		select {
+		case <-w.ready:
+			// Admitted while giving up: hand the bytes back.
+			l.used -= n
+		default:
+			l.waiters.Remove(elem)
+		}
+
+		l.admitWaiters()
+
+		return 0, ctx.Err()
+	}
+}
+
+// release returns n bytes acquired with acquire.
+func (l *byteLimiter) release(n int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.used -= n
+	l.admitWaiters()
+}
+
+// inUse returns the number of bytes currently acquired.
+func (l *byteLimiter) inUse() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.used
+}
+
+// admitWaiters admits waiting requests in order for as long as they fit. It
+// must be called with l.mu held.
+func (l *byteLimiter) admitWaiters() {
+	for elem := l.waiters.Front(); elem != nil; elem = l.waiters.Front() {
+		w := elem.Value.(*byteWaiter)
+
+		if l.used+w.n > l.capacity {
+			return
+		}
+
+		l.used += w.n
+		l.waiters.Remove(elem)
+		close(w.ready)
+	}
+}
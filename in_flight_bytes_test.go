@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestByteLimiter_AcquireRelease(t *testing.T) {
+	t.Parallel()
+
+	l := newByteLimiter(100)
+
+	first, err := l.acquire(context.Background(), 60)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	admitted := make(chan struct{})
+
+	go func() {
+		n, _ := l.acquire(context.Background(), 60)
+		close(admitted)
+		l.release(n)
+	}()
+
+	select {
+	case <-admitted:
+		t.Fatal("expected the second acquire to wait for capacity")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	l.release(first)
+
+	select {
+	case <-admitted:
+	case <-time.After(time.Second):
+		t.Fatal("expected the second acquire to be admitted after release")
+	}
+}
+
+func TestByteLimiter_Oversized(t *testing.T) {
+	t.Parallel()
+
+	l := newByteLimiter(100)
+
+	n, err := l.acquire(context.Background(), 500)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n != 100 || l.inUse() != 100 {
+		t.Errorf("expected an oversized body to take the whole capacity, got %d (in use %d)", n, l.inUse())
+	}
+
+	l.release(n)
+}
+
+func TestByteLimiter_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	l := newByteLimiter(100)
+
+	held, _ := l.acquire(context.Background(), 80)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.acquire(ctx, 50); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	l.release(held)
+
+	if got := l.inUse(); got != 0 {
+		t.Errorf("expected no bytes in use after the cancelled wait, got %d", got)
+	}
+
+	if n, err := l.acquire(context.Background(), 100); err != nil || n != 100 {
+		t.Errorf("expected full capacity to be available, got %d, %v", n, err)
+	}
+}
+
+func TestByteLimiter_FIFO(t *testing.T) {
+	t.Parallel()
+
+	l := newByteLimiter(100)
+
+	held, _ := l.acquire(context.Background(), 50)
+
+	large := make(chan struct{})
+
+	go func() {
+		n, _ := l.acquire(context.Background(), 90)
+		close(large)
+		l.release(n)
+	}()
+
+	// Wait for the large request to queue.
+	for {
+		l.mu.Lock()
+		queued := l.waiters.Len()
+		l.mu.Unlock()
+
+		if queued == 1 {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	small := make(chan struct{})
+
+	go func() {
+		n, _ := l.acquire(context.Background(), 5)
+		close(small)
+		l.release(n)
+	}()
+
+	select {
+	case <-small:
+		t.Fatal("expected the small request to wait behind the large one, although it fits")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	l.release(held)
+
+	<-large
+	<-small
+}
+
+func TestClient_MaxInFlightBytes(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+			<-release
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithMaxInFlightBytes(1000), WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	firstDone := make(chan error, 1)
+
+	go func() {
+		firstDone <- c.Send(context.Background(), &types.Alert{Text: "first"})
+	}()
+
+	for c.inFlightBytes.inUse() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := c.Send(ctx, &types.Alert{Text: "second"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the second send to wait for capacity and time out, got %v", err)
+	}
+
+	close(release)
+
+	if err := <-firstDone; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := c.inFlightBytes.inUse(); got != 0 {
+		t.Errorf("expected no bytes in flight, got %d", got)
+	}
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestClient_CloseWithGrace_WaitsForInFlight(t *testing.T) {
+	t.Parallel()
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			close(entered)
+			<-unblock
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- c.Send(context.Background(), &types.Alert{Header: "test"})
+	}()
+
+	<-entered
+
+	closed := make(chan struct{})
+	go func() {
+		c.CloseWithGrace(time.Second)
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("expected CloseWithGrace to wait for the request in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(unblock)
+
+	if err := <-sent; err != nil {
+		t.Errorf("expected the request in flight to complete, got %v", err)
+	}
+
+	<-closed
+}
+
+func TestClient_CloseWithGrace_CancelsAfterGrace(t *testing.T) {
+	t.Parallel()
+
+	entered := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+			close(entered)
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- c.Send(context.Background(), &types.Alert{Header: "test"})
+	}()
+
+	<-entered
+
+	start := time.Now()
+	c.CloseWithGrace(50 * time.Millisecond)
+
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected CloseWithGrace to wait for the grace period, returned after %v", elapsed)
+	}
+
+	if err := <-sent; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the request in flight to be cancelled, got %v", err)
+	}
+}
+
+func TestClient_CloseWithGrace_Idle(t *testing.T) {
+	t.Parallel()
+
+	var unconnected *Client
+	unconnected.CloseWithGrace(time.Second)
+	New("http://example.com").CloseWithGrace(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	start := time.Now()
+	c.CloseWithGrace(time.Second)
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected an idle client to close immediately, took %v", elapsed)
+	}
+}
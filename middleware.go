@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+
+	"github.com/slackmgr/types"
+)
+
+// SendFunc sends alerts to the API. It is the unit that [SendMiddleware]
+// wraps.
+type SendFunc func(ctx context.Context, alerts ...*types.Alert) error
+
+// SendMiddleware wraps a [SendFunc] to add behaviour around a send, such as
+// logging, metrics or request signing. A middleware may modify the context or
+// alerts before calling next, inspect the error next returns, or return
+// without calling next to short-circuit the send.
+type SendMiddleware func(next SendFunc) SendFunc
+
+// buildSendChain wraps terminal in the configured middleware. The first
+// middleware is the outermost, so it runs first and sees the final error.
+func (c *Client) buildSendChain(terminal SendFunc) SendFunc {
+	next := terminal
+
+	for i := len(c.options.middleware) - 1; i >= 0; i-- {
+		next = c.options.middleware[i](next)
+	}
+
+	return next
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSend_MiddlewareOrder(t *testing.T) {
+	t.Parallel()
+
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			var payload alertsList
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			for _, alert := range payload.Alerts {
+				headers = append(headers, alert.Header)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(name string) SendMiddleware {
+		return func(next SendFunc) SendFunc {
+			return func(ctx context.Context, alerts ...*types.Alert) error {
+				calls = append(calls, name+" before")
+				err := next(ctx, alerts...)
+				calls = append(calls, name+" after")
+				return err
+			}
+		}
+	}
+
+	appendAlert := func(next SendFunc) SendFunc {
+		return func(ctx context.Context, alerts ...*types.Alert) error {
+			return next(ctx, append(alerts, &types.Alert{Header: "added"})...)
+		}
+	}
+
+	c := New(server.URL, WithMiddleware(record("a"), record("b")), WithMiddleware(appendAlert))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "original"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "a before,b before,b after,a after"
+	if got := strings.Join(calls, ","); got != expected {
+		t.Errorf("expected call order %q, got %q", expected, got)
+	}
+
+	if got := strings.Join(headers, ","); got != "original,added" {
+		t.Errorf("expected alerts original,added, got %s", got)
+	}
+}
+
+func TestSend_MiddlewareShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	posted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			posted = true
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	errBlocked := errors.New("blocked")
+	block := func(SendFunc) SendFunc {
+		return func(context.Context, ...*types.Alert) error {
+			return errBlocked
+		}
+	}
+
+	c := New(server.URL, WithMiddleware(block))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	meta, err := c.SendWithResponse(context.Background(), &types.Alert{Header: "test"})
+	if !errors.Is(err, errBlocked) {
+		t.Errorf("expected middleware error, got: %v", err)
+	}
+
+	if meta != nil {
+		t.Errorf("expected nil metadata when no request was made, got %+v", meta)
+	}
+
+	if posted {
+		t.Error("expected no request to be sent")
+	}
+}
+
+func TestSend_MiddlewareInvalidAlerts(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dropAll := func(next SendFunc) SendFunc {
+		return func(ctx context.Context, _ ...*types.Alert) error {
+			return next(ctx)
+		}
+	}
+
+	c := New(server.URL, WithMiddleware(dropAll))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(), &types.Alert{Header: "test"})
+	if err == nil || err.Error() != "alerts list cannot be empty" {
+		t.Errorf("expected empty alerts error, got: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// SendObserver receives callbacks for the lifecycle of every send made by
+// [Client.Send] and its variants, see [WithObserver]. Methods are called
+// synchronously on the sending goroutine, so they should return quickly. A
+// panic in a method is recovered and logged as an error.
+type SendObserver interface {
+	// OnStart is called once the alerts are validated, before anything is
+	// sent. Only Alerts is set.
+	OnStart(event SendEvent)
+
+	// OnRetry is called when a request attempt failed and is about to be
+	// retried. Attempt is the number of the failed attempt, starting at 1;
+	// StatusCode is 0 when no response was received.
+	OnRetry(event SendEvent)
+
+	// OnSuccess is called when the send succeeded.
+	OnSuccess(event SendEvent)
+
+	// OnFailure is called when the send failed. StatusCode is 0 when no
+	// response was received.
+	OnFailure(event SendEvent)
+}
+
+// SendEvent describes a point in the lifecycle of a send, as passed to a
+// [SendObserver]. Fields that do not apply to an event are left zero.
+type SendEvent struct {
+	// Context is the context passed to the send, so that the event can be
+	// correlated with values the caller stored in it.
+	Context context.Context //nolint:containedctx // Carries the caller's values to the observer.
+
+	// Alerts is the number of alerts in the send.
+	Alerts int
+
+	// Attempt is the number of the request attempt, starting at 1.
+	Attempt int
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Err is the error of the failed attempt or send.
+	Err error
+
+	// Duration is the time elapsed since the send started.
+	Duration time.Duration
+
+	// TimeToFirstByte is the time from the start of the attempt to the first
+	// byte of its response, zero if it got none. Success and failure events
+	// report the final attempt.
+	TimeToFirstByte time.Duration
+}
+
+// observedSendKey is the context key under which [Client.send] stores the
+// state of a send reported to the observer.
+type observedSendKey struct{}
+
+type observedSend struct {
+	ctx    context.Context //nolint:containedctx // Reported with every event of the send.
+	alerts int
+	start  time.Time
+}
+
+// observeStart reports the start of a send to the observer and returns a
+// context carrying its state for [Client.observeRetry].
+func (c *Client) observeStart(ctx context.Context, alerts int) (context.Context, *observedSend) {
+	send := &observedSend{ctx: ctx, alerts: alerts, start: time.Now()}
+
+	c.notifyObserver("OnStart", c.options.observer.OnStart, SendEvent{Context: ctx, Alerts: alerts})
+
+	return context.WithValue(ctx, observedSendKey{}, send), send
+}
+
+// observeEnd reports the outcome of a send to the observer.
+func (c *Client) observeEnd(send *observedSend, meta *ResponseMetadata, err error) {
+	event := SendEvent{
+		Context:  send.ctx,
+		Alerts:   send.alerts,
+		Err:      err,
+		Duration: time.Since(send.start),
+	}
+
+	if meta != nil {
+		event.StatusCode = meta.StatusCode
+		event.TimeToFirstByte = meta.TimeToFirstByte
+	}
+
+	if err != nil {
+		c.notifyObserver("OnFailure", c.options.observer.OnFailure, event)
+	} else {
+		c.notifyObserver("OnSuccess", c.options.observer.OnSuccess, event)
+	}
+}
+
+// observeRetry is a resty retry hook reporting retried send attempts to the
+// observer. Requests not made by a send, such as pings, are not reported.
+func (c *Client) observeRetry(r *resty.Response, err error) {
+	if r == nil || r.Request == nil {
+		return
+	}
+
+	send, ok := r.Request.Context().Value(observedSendKey{}).(*observedSend)
+	if !ok {
+		return
+	}
+
+	// resty also runs retry hooks after the last attempt, when no retry
+	// follows.
+	if r.Request.Attempt > c.options.retryCount {
+		return
+	}
+
+	event := SendEvent{
+		Context:  send.ctx,
+		Alerts:   send.alerts,
+		Attempt:  r.Request.Attempt,
+		Err:      err,
+		Duration: time.Since(send.start),
+	}
+
+	if r.RawResponse != nil {
+		event.StatusCode = r.StatusCode()
+		event.TimeToFirstByte = timeToFirstByte(r)
+	}
+
+	c.notifyObserver("OnRetry", c.options.observer.OnRetry, event)
+}
+
+// notifyObserver calls fn with event, recovering from and logging a panic.
+func (c *Client) notifyObserver(method string, fn func(SendEvent), event SendEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.options.requestLogger.Errorf("send observer panicked in %s: %v", method, r)
+		}
+	}()
+
+	fn(event)
+}
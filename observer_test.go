@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	names  []string
+	events []SendEvent
+	panics bool
+}
+
+func (o *recordingObserver) record(name string, event SendEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.names = append(o.names, name)
+	o.events = append(o.events, event)
+
+	if o.panics {
+		panic("observer failure")
+	}
+}
+
+func (o *recordingObserver) OnStart(event SendEvent)   { o.record("start", event) }
+func (o *recordingObserver) OnRetry(event SendEvent)   { o.record("retry", event) }
+func (o *recordingObserver) OnSuccess(event SendEvent) { o.record("success", event) }
+func (o *recordingObserver) OnFailure(event SendEvent) { o.record("failure", event) }
+
+func (o *recordingObserver) snapshot() ([]string, []SendEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.names, o.events
+}
+
+func TestObserver_RetryThenSuccess(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	observer := &recordingObserver{}
+
+	c := New(server.URL, WithObserver(observer), WithRetryCount(2), WithRetryWaitTime(100*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if names, _ := observer.snapshot(); len(names) != 0 {
+		t.Fatalf("expected the connect ping not to be observed, got %v", names)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{}, &types.Alert{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names, events := observer.snapshot()
+
+	if strings.Join(names, ",") != "start,retry,success" {
+		t.Fatalf("expected start,retry,success, got %v", names)
+	}
+
+	retryEvent, last := events[1], events[2]
+
+	if retryEvent.Attempt != 1 || retryEvent.StatusCode != http.StatusServiceUnavailable || retryEvent.Alerts != 2 {
+		t.Errorf("unexpected retry event: %+v", retryEvent)
+	}
+
+	if last.StatusCode != http.StatusOK || last.Alerts != 2 || last.Err != nil || last.Duration <= 0 {
+		t.Errorf("unexpected success event: %+v", last)
+	}
+
+	if retryEvent.TimeToFirstByte <= 0 || last.TimeToFirstByte <= 0 {
+		t.Errorf("expected the time to first byte of each attempt, got %v and %v", retryEvent.TimeToFirstByte, last.TimeToFirstByte)
+	}
+}
+
+func TestObserver_EventsCarrySendContext(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	observer := &recordingObserver{}
+
+	c := New(server.URL, WithObserver(observer), WithRetryCount(1), WithRetryWaitTime(100*time.Millisecond))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	type jobKey struct{}
+
+	ctx := context.WithValue(context.Background(), jobKey{}, "nightly-import")
+
+	if err := c.Send(ctx, &types.Alert{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names, events := observer.snapshot()
+
+	if len(events) != 3 {
+		t.Fatalf("expected start, retry and success events, got %v", names)
+	}
+
+	for i, event := range events {
+		if event.Context == nil || event.Context.Value(jobKey{}) != "nightly-import" {
+			t.Errorf("expected the %s event to carry the send context", names[i])
+		}
+	}
+}
+
+func TestHooksCarrySendContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "10")
+
+		if body, _ := io.ReadAll(r.Body); strings.Contains(string(body), "reject") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	type jobKey struct{}
+
+	var (
+		mu    sync.Mutex
+		calls = map[string]int{}
+	)
+
+	// Each hook counts its calls made with the send context
+	record := func(hook string, ctx context.Context) {
+		if ctx != nil && ctx.Value(jobKey{}) == "nightly-import" {
+			mu.Lock()
+			calls[hook]++
+			mu.Unlock()
+		}
+	}
+
+	c := New(server.URL,
+		WithRetryCount(0),
+		WithTimingCallback(func(ctx context.Context, _ RequestTimings) { record("timing", ctx) }),
+		WithStatusFamilyCallback(func(ctx context.Context, _ int) { record("status family", ctx) }),
+		WithRateLimitHeaders(func(ctx context.Context, _ int, _ time.Time) { record("rate limit headers", ctx) }),
+		WithTreatBodyErrorsAsFailure(func(ctx context.Context, _ []byte) error {
+			record("body check", ctx)
+			return nil
+		}),
+		WithBatchCallback(func(ctx context.Context, _, _ int, _ error) { record("batch", ctx) }),
+		WithDeliveryFailureSink(func(ctx context.Context, _ []*types.Alert, _ error) { record("delivery failure sink", ctx) }),
+	)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), jobKey{}, "nightly-import")
+
+	if err := c.SendBatchBySize(ctx, 1<<20, &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.Send(ctx, &types.Alert{Text: "reject"}); err == nil {
+		t.Fatal("expected the send to fail")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, hook := range []string{"timing", "status family", "rate limit headers", "body check", "batch", "delivery failure sink"} {
+		if calls[hook] == 0 {
+			t.Errorf("expected the %s hook to get the send context", hook)
+		}
+	}
+}
+
+func TestObserver_Failure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	observer := &recordingObserver{}
+
+	c := New(server.URL, WithObserver(observer))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	sendErr := c.Send(context.Background(), &types.Alert{})
+	if sendErr == nil {
+		t.Fatal("expected error")
+	}
+
+	names, events := observer.snapshot()
+
+	if strings.Join(names, ",") != "start,failure" {
+		t.Fatalf("expected start,failure, got %v", names)
+	}
+
+	if last := events[1]; last.StatusCode != http.StatusBadRequest || last.Err != sendErr {
+		t.Errorf("unexpected failure event: %+v", last)
+	}
+}
+
+func TestObserver_PanicRecovered(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := &recordingLogger{}
+
+	c := New(server.URL, WithObserver(&recordingObserver{panics: true}), WithRequestLogger(logger))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(logger.String(), "error: send observer panicked in OnStart: observer failure") {
+		t.Errorf("expected the panic to be logged, got:\n%s", logger.String())
+	}
+}
@@ -1,13 +1,21 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
 )
 
 const (
@@ -32,6 +40,15 @@ const (
 	defaultAuthScheme      = "Bearer"
 	defaultAlertsEndpoint  = "alerts"
 	defaultPingEndpoint    = "ping"
+	defaultResolveParam    = "state"
+	defaultResolveValue    = "resolved"
+	defaultErrorPath       = "error"
+	defaultDialNetwork     = "tcp"
+	maxPingTolerance       = 100
+
+	defaultMaxResponseHeaderBytes = 64 << 10
+	minMaxResponseHeaderBytes     = 4 << 10
+	maxMaxResponseHeaderBytes     = 1 << 20
 )
 
 // Option is a functional option for configuring a [Client].
@@ -40,26 +57,103 @@ type Option func(*Options)
 // Options holds the configuration for a [Client]. Use [Option] functions
 // such as [WithRetryCount] or [WithAuthToken] to customise the defaults.
 type Options struct {
-	retryCount        int
-	retryWaitTime     time.Duration
-	retryMaxWaitTime  time.Duration
-	requestLogger     RequestLogger
-	retryPolicy       func(*resty.Response, error) bool
-	requestHeaders    map[string]string
-	basicAuthUsername string
-	basicAuthPassword string
-	authScheme        string
-	authToken         string
-	timeout           time.Duration
-	userAgent         string
-	maxIdleConns      int
-	maxConnsPerHost   int
-	idleConnTimeout   time.Duration
-	disableKeepAlive  bool
-	maxRedirects      int
-	tlsConfig         *tls.Config
-	alertsEndpoint    string
-	pingEndpoint      string
+	retryCount              int
+	retryWaitTime           time.Duration
+	retryMaxWaitTime        time.Duration
+	requestLogger           RequestLogger
+	retryPolicy             func(*resty.Response, error) bool
+	requestHeaders          map[string]string
+	basicAuthUsername       string
+	basicAuthPassword       string
+	authScheme              string
+	authToken               string
+	timeout                 time.Duration
+	userAgent               string
+	maxIdleConns            int
+	maxConnsPerHost         int
+	idleConnTimeout         time.Duration
+	disableKeepAlive        bool
+	maxRedirects            int
+	tlsConfig               *tls.Config
+	alertsEndpoint          string
+	pingEndpoint            string
+	severityEndpoints       map[string]string
+	timingCallback          func(ctx context.Context, timings RequestTimings)
+	bodyErrorCheck          func(ctx context.Context, body []byte) error
+	maxSendDuration         time.Duration
+	resolveParam            string
+	resolveValue            string
+	globalLabels            map[string]string
+	errorMessagePath        string
+	poolStats               bool
+	middleware              []SendMiddleware
+	statusFamilyFn          func(ctx context.Context, family int)
+	rateLimit               float64
+	rateLimitBurst          int
+	sortLess                func(a, b *types.Alert) bool
+	pingTolerance           int
+	pingInterval            time.Duration
+	connMaxLifetime         time.Duration
+	connectProbes           []connectProbe
+	connectFallback         connectProbe
+	alertValidator          func(alert *types.Alert) error
+	minSeverity             types.AlertSeverity
+	streamingEncode         bool
+	deliveryFailureSink     func(ctx context.Context, failedAlerts []*types.Alert, err error)
+	batchCallback           func(ctx context.Context, batchIndex, batchSize int, err error)
+	connectJitter           time.Duration
+	verifyResponseChecksum  bool
+	alertKey                func(alert *types.Alert) string
+	retryMode               RetryMode
+	payloadValidator        func(body []byte) error
+	rateLimitHeadersFn      func(ctx context.Context, remaining int, resetAt time.Time)
+	endpointResolver        func(alerts []*types.Alert) string
+	acceptCompression       bool
+	clock                   Clock
+	randSource              rand.Source
+	autoTimestamp           bool
+	certificatePins         []string
+	tlsCipherSuites         []uint16
+	clientCertReloader      func() (tls.Certificate, error)
+	auditWriter             io.Writer
+	retryBodySubstrings     []string
+	maxResponseHeaders      int
+	profile                 string
+	profileOverrides        profileField
+	debug                   bool
+	expectedPingBody        string
+	sharedTransport         *http.Transport
+	largeBatchThreshold     int
+	defaultRequestTimeout   time.Duration
+	indentJSON              bool
+	maxRetryAfter           time.Duration
+	encryptionKey           []byte
+	encryptedFields         []string
+	dialNetwork             string
+	statusLogging           bool
+	statusLogLevels         map[int]string
+	noRetryAboveBodySize    int
+	responseTimeoutRetry    bool
+	envelopeMetadata        map[string]string
+	observer                SendObserver
+	validateConnOnUse       bool
+	redirectPolicy          func(req *http.Request, via []*http.Request) error
+	preferredAPIVersion     string
+	strictVersioning        bool
+	throttleBaseDelay       time.Duration
+	throttleMaxDelay        time.Duration
+	expectedResponseVersion string
+	maxInFlightBytes        int64
+	drainCtx                context.Context
+	maxLabelsPerAlert       int
+	truncateExcessLabels    bool
+}
+
+// connectProbe is an endpoint checked by [Client.Connect], see
+// [WithConnectProbes].
+type connectProbe struct {
+	method string
+	path   string
 }
 
 func newClientOptions() *Options {
@@ -73,19 +167,122 @@ func newClientOptions() *Options {
 			"Content-Type": "application/json",
 			"Accept":       "application/json",
 		},
-		timeout:          defaultTimeout,
-		userAgent:        defaultUserAgent,
-		maxIdleConns:     defaultMaxIdleConns,
-		maxConnsPerHost:  defaultMaxConnsPerHost,
-		idleConnTimeout:  defaultIdleConnTimeout,
-		disableKeepAlive: false,
-		maxRedirects:     defaultMaxRedirects,
-		authScheme:       defaultAuthScheme,
-		alertsEndpoint:   defaultAlertsEndpoint,
-		pingEndpoint:     defaultPingEndpoint,
+		timeout:            defaultTimeout,
+		userAgent:          defaultUserAgent,
+		maxIdleConns:       defaultMaxIdleConns,
+		maxConnsPerHost:    defaultMaxConnsPerHost,
+		idleConnTimeout:    defaultIdleConnTimeout,
+		disableKeepAlive:   false,
+		maxRedirects:       defaultMaxRedirects,
+		authScheme:         defaultAuthScheme,
+		alertsEndpoint:     defaultAlertsEndpoint,
+		pingEndpoint:       defaultPingEndpoint,
+		severityEndpoints:  map[string]string{},
+		resolveParam:       defaultResolveParam,
+		resolveValue:       defaultResolveValue,
+		globalLabels:       map[string]string{},
+		errorMessagePath:   defaultErrorPath,
+		acceptCompression:  true,
+		clock:              systemClock{},
+		randSource:         globalRandSource{},
+		alertKey:           defaultAlertKey,
+		maxResponseHeaders: defaultMaxResponseHeaderBytes,
+		dialNetwork:        defaultDialNetwork,
+		statusLogLevels:    defaultStatusLogLevels(),
 	}
 }
 
+// DefaultOptions returns a fresh [Options] populated with the package
+// defaults, as used by [New]. Credentials are never populated. Use
+// [Options.Apply] to layer further options on top, for example when a
+// wrapper library builds its own presets from the same defaults, and
+// [NewFromOptions] to create a client from the result.
+func DefaultOptions() *Options {
+	return newClientOptions()
+}
+
+// Apply applies opts to o in order and returns o, so calls can be chained.
+// A profile selected with [WithProfile] is applied after opts, whatever its
+// position, and only to the settings opts leave alone, so that the other
+// options override it. Each option runs once.
+//
+// The profile is resolved per call: it only yields to the options of the
+// same call. A profile selected by a later Apply call overrides the options
+// set by earlier calls, so pass the profile and the options meant to
+// override it together.
+func (o *Options) Apply(opts ...Option) *Options {
+	previous := o.profile
+	o.profile = ""
+	o.profileOverrides = 0
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	// The profile is applied last, skipping the fields the options set
+	if o.profile == "" {
+		o.profile = previous
+	} else if profileOpts, ok := profileOptions(o.profile); ok {
+		for _, opt := range profileOpts {
+			opt(o)
+		}
+	}
+
+	o.profileOverrides = 0
+
+	return o
+}
+
+// RetryCount returns the number of retry attempts, see [WithRetryCount].
+func (o *Options) RetryCount() int {
+	return o.retryCount
+}
+
+// RetryWaitTime returns the initial wait between retries, see
+// [WithRetryWaitTime].
+func (o *Options) RetryWaitTime() time.Duration {
+	return o.retryWaitTime
+}
+
+// RetryMaxWaitTime returns the maximum wait between retries, see
+// [WithRetryMaxWaitTime].
+func (o *Options) RetryMaxWaitTime() time.Duration {
+	return o.retryMaxWaitTime
+}
+
+// Timeout returns the request timeout, see [WithTimeout].
+func (o *Options) Timeout() time.Duration {
+	return o.timeout
+}
+
+// Profile returns the name of the profile selected with [WithProfile], or
+// an empty string.
+func (o *Options) Profile() string {
+	return o.profile
+}
+
+// clone returns a copy of o that shares none of its maps and slices, so
+// that changes to either copy do not leak into the other. Callbacks, the
+// TLS config and other pointers are shared.
+func (o *Options) clone() *Options {
+	c := *o
+
+	c.requestHeaders = maps.Clone(o.requestHeaders)
+	c.severityEndpoints = maps.Clone(o.severityEndpoints)
+	c.globalLabels = maps.Clone(o.globalLabels)
+	c.statusLogLevels = maps.Clone(o.statusLogLevels)
+	c.envelopeMetadata = maps.Clone(o.envelopeMetadata)
+	c.middleware = slices.Clone(o.middleware)
+	c.connectProbes = slices.Clone(o.connectProbes)
+	c.certificatePins = slices.Clone(o.certificatePins)
+	c.tlsCipherSuites = slices.Clone(o.tlsCipherSuites)
+	c.retryBodySubstrings = slices.Clone(o.retryBodySubstrings)
+	c.encryptionKey = slices.Clone(o.encryptionKey)
+	c.encryptedFields = slices.Clone(o.encryptedFields)
+
+	return &c
+}
+
 // WithRetryCount sets the number of retry attempts for failed requests.
 // The default is 3. The maximum allowed value is 100. Negative values are
 // silently ignored and the default is retained.
@@ -93,6 +290,7 @@ func WithRetryCount(count int) Option {
 	return func(o *Options) {
 		if count >= 0 {
 			o.retryCount = count
+			o.profileOverrides |= profileRetryCount
 		}
 	}
 }
@@ -104,6 +302,7 @@ func WithRetryWaitTime(waitTime time.Duration) Option {
 	return func(o *Options) {
 		if waitTime >= 100*time.Millisecond {
 			o.retryWaitTime = waitTime
+			o.profileOverrides |= profileRetryWaitTime
 		}
 	}
 }
@@ -116,6 +315,7 @@ func WithRetryMaxWaitTime(maxWaitTime time.Duration) Option {
 	return func(o *Options) {
 		if maxWaitTime >= 100*time.Millisecond {
 			o.retryMaxWaitTime = maxWaitTime
+			o.profileOverrides |= profileRetryMaxWaitTime
 		}
 	}
 }
@@ -146,6 +346,21 @@ func WithRetryPolicy(policy func(*resty.Response, error) bool) Option {
 	}
 }
 
+// WithRetryMode selects which failures the retry policy acts on: all of them
+// with [RetryAll], only failures without a response, such as a reset
+// connection, with [RetryConnectionOnly], or none with [RetryNone]. Use
+// RetryConnectionOnly when status codes such as 5xx are handled by the
+// caller, to avoid amplifying the load on a struggling server. It is a
+// simpler alternative to a custom [WithRetryPolicy] for these cases. The
+// default is RetryAll. Unknown modes are silently ignored.
+func WithRetryMode(mode RetryMode) Option {
+	return func(o *Options) {
+		if mode.valid() {
+			o.retryMode = mode
+		}
+	}
+}
+
 // WithRequestHeader adds a custom header to all requests. Both the header
 // name and value are trimmed of leading and trailing whitespace. Empty
 // header names and attempts to override the protected Content-Type and
@@ -155,7 +370,7 @@ func WithRequestHeader(header, value string) Option {
 		header = strings.TrimSpace(header)
 		value = strings.TrimSpace(value)
 
-		if header == "" || strings.EqualFold(header, "Content-Type") || strings.EqualFold(header, "Accept") {
+		if header == "" || isProtectedHeader(header) {
 			return
 		}
 
@@ -163,6 +378,12 @@ func WithRequestHeader(header, value string) Option {
 	}
 }
 
+// isProtectedHeader reports whether header is one the client always sets
+// itself and must not be overridden.
+func isProtectedHeader(header string) bool {
+	return strings.EqualFold(header, "Content-Type") || strings.EqualFold(header, "Accept")
+}
+
 // WithBasicAuth configures HTTP Basic authentication. Mutually exclusive
 // with [WithAuthToken]; supplying both is rejected when [Client.Connect]
 // is called.
@@ -197,6 +418,7 @@ func WithTimeout(timeout time.Duration) Option {
 	return func(o *Options) {
 		if timeout >= minTimeout && timeout <= maxTimeout {
 			o.timeout = timeout
+			o.profileOverrides |= profileTimeout
 		}
 	}
 }
@@ -242,6 +464,7 @@ func WithIdleConnTimeout(timeout time.Duration) Option {
 	return func(o *Options) {
 		if timeout >= minIdleConnTimeout && timeout <= maxIdleConnTimeout {
 			o.idleConnTimeout = timeout
+			o.profileOverrides |= profileIdleConnTimeout
 		}
 	}
 }
@@ -274,6 +497,7 @@ func WithTLSConfig(config *tls.Config) Option {
 	return func(o *Options) {
 		if config != nil {
 			o.tlsConfig = config
+			o.profileOverrides |= profileTLSConfig
 		}
 	}
 }
@@ -302,6 +526,977 @@ func WithPingEndpoint(endpoint string) Option {
 	}
 }
 
+// WithSeverityEndpoint routes alerts with the given severity to a dedicated
+// API endpoint path. Supply it once per severity to build up the mapping.
+// Alerts whose severity has no mapping are sent to the default alerts
+// endpoint (see [WithAlertsEndpoint]). Both values are trimmed of leading
+// and trailing whitespace; empty values are silently ignored.
+func WithSeverityEndpoint(severity, endpoint string) Option {
+	return func(o *Options) {
+		severity = strings.TrimSpace(severity)
+		endpoint = strings.TrimSpace(endpoint)
+
+		if severity == "" || endpoint == "" {
+			return
+		}
+
+		o.severityEndpoints[severity] = endpoint
+	}
+}
+
+// WithTimingCallback sets a function that receives the [RequestTimings] of
+// every HTTP request made by the client, including the connect-time ping.
+// The callback is invoked synchronously once the request completes, with the
+// context of the call, so it should return quickly. Tracing is only enabled
+// when a callback is set. Nil values are silently ignored.
+func WithTimingCallback(fn func(ctx context.Context, timings RequestTimings)) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.timingCallback = fn
+		}
+	}
+}
+
+// WithTreatBodyErrorsAsFailure sets a function that inspects the body of every
+// successful (2xx) response to a send. A non-nil return value turns the
+// success into a failure, wrapping the returned error. Use this with gateways
+// that report logical errors in a 200 response, such as a GraphQL-style
+// "errors" array. The function gets the context of the send. Nil values are
+// silently ignored.
+func WithTreatBodyErrorsAsFailure(fn func(ctx context.Context, body []byte) error) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.bodyErrorCheck = fn
+		}
+	}
+}
+
+// WithMaxSendDuration sets an absolute ceiling on how long a single send may
+// take, covering marshaling and all retry attempts. When the ceiling is
+// reached the send is cancelled and an error wrapping
+// [ErrSendDeadlineExceeded] is returned, regardless of the retry settings or
+// the caller's context. The default is 0, meaning no ceiling. Non-positive
+// values are silently ignored.
+func WithMaxSendDuration(d time.Duration) Option {
+	return func(o *Options) {
+		if d > 0 {
+			o.maxSendDuration = d
+		}
+	}
+}
+
+// WithResolveQueryParam sets the query parameter that [Client.Resolve] adds to
+// mark alerts as resolved. The default is "state=resolved". Both values are
+// trimmed of leading and trailing whitespace; empty values are silently
+// ignored and the default is retained.
+func WithResolveQueryParam(name, value string) Option {
+	return func(o *Options) {
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+
+		if name == "" || value == "" {
+			return
+		}
+
+		o.resolveParam = name
+		o.resolveValue = value
+	}
+}
+
+// WithGlobalLabels sets key-value labels that are added to every alert sent by
+// the client. [types.Alert] has no dedicated labels field, so the labels are
+// merged into each alert's Metadata map. Alert-local metadata takes precedence
+// over a global label with the same key. Alerts are cloned before merging, so
+// the caller's alerts are never modified. Repeated calls accumulate. Keys are
+// trimmed of leading and trailing whitespace; empty keys are silently ignored.
+func WithGlobalLabels(labels map[string]string) Option {
+	return func(o *Options) {
+		for key, value := range labels {
+			key = strings.TrimSpace(key)
+			if key != "" {
+				o.globalLabels[key] = value
+			}
+		}
+	}
+}
+
+// WithEnvelopeMetadata adds a "meta" object to the request envelope, next to
+// the alerts, to help trace which producer sent them. It holds the hostname,
+// process ID and client version of the sender under the keys "hostname",
+// "pid" and "client_version", merged with meta, whose values take
+// precedence. Repeated calls accumulate. Keys are trimmed of leading and
+// trailing whitespace; empty keys are silently ignored. By default the
+// envelope has no meta object.
+func WithEnvelopeMetadata(meta map[string]string) Option {
+	return func(o *Options) {
+		if o.envelopeMetadata == nil {
+			o.envelopeMetadata = make(map[string]string, len(meta))
+		}
+
+		for key, value := range meta {
+			key = strings.TrimSpace(key)
+			if key != "" {
+				o.envelopeMetadata[key] = value
+			}
+		}
+	}
+}
+
+// WithErrorMessagePath sets the location of the error message in JSON error
+// responses, as a dot-separated path of object keys such as
+// "data.error.message". The default is "error", i.e. a top-level "error"
+// field. When the path does not resolve to a non-empty string, the raw
+// response body is used instead. Empty and whitespace-only values are
+// silently ignored and the default is retained.
+func WithErrorMessagePath(path string) Option {
+	return func(o *Options) {
+		path = strings.TrimSpace(path)
+		if path != "" {
+			o.errorMessagePath = path
+		}
+	}
+}
+
+// WithPoolStats enables connection pool instrumentation, reported through
+// [Client.PoolStats]. Use it to see whether [WithMaxConnsPerHost] is a
+// bottleneck during bursts. The default is false.
+func WithPoolStats(enabled bool) Option {
+	return func(o *Options) {
+		o.poolStats = enabled
+	}
+}
+
+// WithMiddleware appends middleware to the chain every send passes through,
+// including [Client.Send], [Client.SendWithResponse] and [Client.Resolve].
+// Middleware runs in the order given, across repeated calls: the first
+// middleware is the outermost. Nil values are silently ignored.
+func WithMiddleware(mw ...SendMiddleware) Option {
+	return func(o *Options) {
+		for _, m := range mw {
+			if m != nil {
+				o.middleware = append(o.middleware, m)
+			}
+		}
+	}
+}
+
+// WithStatusFamilyCallback sets a function that is called with the status
+// family (the status code divided by 100, e.g. 2 for 2xx) of every HTTP
+// response the client receives, including the connect-time ping, along with
+// the context of the call. It fires for error responses too, but not when no
+// response arrives at all. Nil values are silently ignored.
+func WithStatusFamilyCallback(fn func(ctx context.Context, family int)) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.statusFamilyFn = fn
+		}
+	}
+}
+
+// WithPerHostRateLimit limits how many HTTP requests per second the client
+// makes to each host, with bursts of up to burst requests. Every host gets
+// its own token bucket, created on first use, so a slow host cannot consume
+// the budget of another. Every attempt, including retries and the
+// connect-time ping, takes a token; requests wait for a token while
+// respecting their context. The default is no limit. Non-positive values are
+// silently ignored.
+func WithPerHostRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(o *Options) {
+		if requestsPerSecond > 0 && burst >= 1 {
+			o.rateLimit = requestsPerSecond
+			o.rateLimitBurst = burst
+		}
+	}
+}
+
+// WithSortAlerts sets a less function used to order the alerts in each
+// request body, for example by header and then text. The sort is stable and
+// is applied to a copy, so the caller's slice is not reordered. The default
+// is nil, which preserves the order the alerts were passed in. Nil values
+// are silently ignored.
+func WithSortAlerts(less func(a, b *types.Alert) bool) Option {
+	return func(o *Options) {
+		if less != nil {
+			o.sortLess = less
+		}
+	}
+}
+
+// WithConnectPingTolerance lets [Client.Connect] tolerate up to failures
+// consecutive failed pings, waiting interval between them, before giving up.
+// This smooths over brief unavailability during server deploys. Each ping
+// still uses the configured retry policy, and the wait respects the connect
+// context's deadline. The default is 0, meaning the first failed ping fails
+// Connect. The maximum is 100. Out-of-range values and non-positive
+// intervals are silently ignored.
+func WithConnectPingTolerance(failures int, interval time.Duration) Option {
+	return func(o *Options) {
+		if failures >= 0 && failures <= maxPingTolerance && interval > 0 {
+			o.pingTolerance = failures
+			o.pingInterval = interval
+			o.profileOverrides |= profilePingTolerance
+		}
+	}
+}
+
+// WithConnMaxLifetime sets the maximum time a connection may stay open.
+// Once a connection is older than d it is closed as soon as it is idle, and
+// the next request dials a new one. Behind a load balancer this spreads
+// long-lived clients across newly added backends over time. The default is
+// 0, meaning connections are never recycled. Negative values are silently
+// ignored.
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(o *Options) {
+		if d >= 0 {
+			o.connMaxLifetime = d
+		}
+	}
+}
+
+// WithConnectProbes adds endpoints that [Client.Connect] checks after the
+// ping, failing unless each one answers with a 2xx status. This catches a
+// server that serves the ping but has other routes misconfigured at startup
+// rather than on the first send. Each probe is a path, checked with GET, or
+// a method and path separated by a space, e.g. "OPTIONS alerts". Only GET,
+// HEAD and OPTIONS are supported. Empty probes are silently ignored.
+// Multiple calls accumulate probes.
+func WithConnectProbes(paths ...string) Option {
+	return func(o *Options) {
+		for _, p := range paths {
+			p = strings.TrimSpace(p)
+			if p == "" {
+				continue
+			}
+
+			probe := connectProbe{method: resty.MethodGet, path: p}
+
+			if method, path, ok := strings.Cut(p, " "); ok {
+				probe = connectProbe{method: strings.ToUpper(method), path: strings.TrimSpace(path)}
+			}
+
+			o.connectProbes = append(o.connectProbes, probe)
+		}
+	}
+}
+
+// WithAlertValidator sets a function that checks every alert before it is
+// sent, for constraints of the server that the client does not know about,
+// such as a maximum text length or an allowed set of severities. It runs on
+// the alerts as passed to the send, before any middleware or transformation,
+// and a rejected alert fails the send with "alert at index N: <err>" before
+// anything is sent. The default is no validation. A nil function is
+// silently ignored.
+func WithAlertValidator(fn func(alert *types.Alert) error) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.alertValidator = fn
+		}
+	}
+}
+
+// WithAlertKey sets the function that returns the identity of an alert, so
+// that it is defined once for every feature that matches alerts with each
+// other. It is used by [Client.SendDiff] when called with a nil key
+// function. Alerts with the same key are considered the same alert, e.g.
+// the correlation ID for alerts that keep it while their text changes. The
+// default is a hash of the header, text and severity. A nil function is
+// silently ignored.
+func WithAlertKey(fn func(alert *types.Alert) string) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.alertKey = fn
+		}
+	}
+}
+
+// WithVerifyResponseChecksum controls whether response bodies are checked
+// against the hex-encoded SHA-256 in their X-Body-SHA256 header, to catch
+// bodies corrupted by a broken intermediary before they are parsed. The
+// checksum covers the body after any decompression. A mismatch fails the
+// request with an error wrapping [ErrResponseChecksumMismatch], and is not
+// retried; a response without the header is not checked. The default is
+// false.
+func WithVerifyResponseChecksum(enabled bool) Option {
+	return func(o *Options) {
+		o.verifyResponseChecksum = enabled
+	}
+}
+
+// WithConnectJitter makes [Client.Connect] wait for a random duration below
+// maxWait before its ping, to spread the load when many clients start at
+// once, e.g. after a fleet-wide deploy. The wait is drawn from the source
+// set with [WithRandSource]. Connect fails if its context is done during the
+// wait. The default of zero pings straight away. Negative values are
+// silently ignored.
+func WithConnectJitter(maxWait time.Duration) Option {
+	return func(o *Options) {
+		if maxWait >= 0 {
+			o.connectJitter = maxWait
+		}
+	}
+}
+
+// WithBatchCallback sets a function that [Client.SendBatchBySize] calls
+// after sending each batch, with the context of the call, the zero-based
+// index of the batch, its number of alerts and the error of its send, for
+// example to report
+// progress while a large set of alerts is sent. It is also called for the
+// batch that fails, after which no further batches are sent. A batch whose
+// alerts are all dropped by [WithMinSeverity] is reported with
+// [ErrNoAlertsAfterFilter], and the next batches are still sent. The default
+// is no callback. A nil function is silently ignored.
+func WithBatchCallback(fn func(ctx context.Context, batchIndex, batchSize int, err error)) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.batchCallback = fn
+		}
+	}
+}
+
+// WithDeliveryFailureSink sets a function that is called when a send fails
+// to deliver its alerts, after all retries, so that the failure can be
+// reported through a channel that does not depend on the API, such as email,
+// a local file or a secondary pager. It is called once per failed send with
+// the alerts that were not delivered, as they would have been sent, and the
+// error returned by the send. When [WithSeverityEndpoint] splits a send,
+// only the alerts of the failed groups are passed. Sends rejected before
+// delivery, by validation, a middleware or [WithDrainContext], do not call
+// it. It runs synchronously, before the send returns, with the context of
+// the send, which may already be done. The default is no sink. A nil
+// function is silently ignored.
+func WithDeliveryFailureSink(fn func(ctx context.Context, failedAlerts []*types.Alert, err error)) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.deliveryFailureSink = fn
+		}
+	}
+}
+
+// WithMinSeverity drops alerts below severity from every send, so that a
+// threshold such as "only warnings and above" is configured once rather
+// than at each call. Severities are ordered info < warning < error < panic,
+// as defined by [types.SeverityPriority]; an alert without a severity
+// counts as error, like on the server. Resolved alerts are always sent, as
+// they clear issues raised earlier, and so are alerts with an unknown
+// severity. The number of dropped alerts is logged at debug level, and a
+// send whose alerts are all dropped returns [ErrNoAlertsAfterFilter]. The
+// default is no filtering. Unknown severities are silently ignored.
+func WithMinSeverity(severity string) Option {
+	return func(o *Options) {
+		normalized := normalizeSeverity(types.AlertSeverity(severity))
+		if strings.TrimSpace(severity) != "" && types.SeverityIsValid(normalized) && normalized != types.AlertResolved {
+			o.minSeverity = normalized
+		}
+	}
+}
+
+// WithConnectFallbackEndpoint makes [Client.Connect] check path with method
+// when the ping fails, and connect anyway if it answers with a 2xx status.
+// This lets the client start while the ping endpoint is down but the rest
+// of the API works, e.g. with "alerts" and "OPTIONS". Connect only fails
+// when both the ping and the fallback fail, and logs a warning when it
+// connects through the fallback. Version negotiation with
+// [WithPreferredAPIVersion] then sees no advertised versions. Only GET,
+// HEAD and OPTIONS are supported; an empty method means HEAD. The default
+// is no fallback. An empty path is silently ignored.
+func WithConnectFallbackEndpoint(path, method string) Option {
+	return func(o *Options) {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return
+		}
+
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			method = resty.MethodHead
+		}
+
+		o.connectFallback = connectProbe{method: method, path: path}
+	}
+}
+
+// WithPayloadValidator sets a function that checks every encoded request
+// body before it is sent. When it returns an error the request is not made,
+// and the send fails with an error wrapping both [ErrPayloadValidation] and
+// the validator's error. This lets callers plug in a JSON Schema validator or
+// any other check without the client depending on one. The default is no
+// validation. A nil function is silently ignored.
+func WithPayloadValidator(fn func(body []byte) error) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.payloadValidator = fn
+		}
+	}
+}
+
+// WithRateLimitHeaders sets a callback that receives the server's rate limit
+// feedback after every response carrying an X-RateLimit-Remaining header,
+// including ping and retried attempts. resetAt is parsed from
+// X-RateLimit-Reset, given either as a Unix timestamp or as seconds from now,
+// and is the zero time when absent. Use it to slow down before hitting a 429,
+// for example by lowering the send rate when remaining runs low. The callback
+// runs synchronously on the request path, with the context of the request,
+// and must not block. A nil callback is silently ignored.
+func WithRateLimitHeaders(fn func(ctx context.Context, remaining int, resetAt time.Time)) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.rateLimitHeadersFn = fn
+		}
+	}
+}
+
+// WithAlertsEndpointResolver sets a callback that computes the alerts
+// endpoint at send time, overriding [WithAlertsEndpoint]. It is called once
+// per send with the alerts being sent, after [WithGlobalLabels] has been
+// applied, so it can route on any alert field, e.g. a region label. An empty
+// result falls back to the configured alerts endpoint. Alerts routed by
+// [WithSeverityEndpoint] keep their dedicated endpoint; the rest are sent to
+// the resolved one. The callback must not modify the alerts. A nil callback
+// is silently ignored.
+func WithAlertsEndpointResolver(fn func(alerts []*types.Alert) string) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.endpointResolver = fn
+		}
+	}
+}
+
+// WithAcceptCompression controls whether responses may be compressed. When
+// enabled, requests advertise "Accept-Encoding: gzip" and gzip-encoded
+// responses, including error bodies, are decompressed transparently before
+// they are inspected. Disable it to request uncompressed responses, for
+// example when a proxy mishandles compression. Setting an Accept-Encoding
+// header with [WithRequestHeader] takes precedence. The default is true.
+func WithAcceptCompression(enabled bool) Option {
+	return func(o *Options) {
+		o.acceptCompression = enabled
+	}
+}
+
+// WithClock sets the [Clock] the client reads the current time from, for
+// example to stamp alerts with [WithAutoTimestamp]. Use it to inject a fake
+// clock in tests. The default is the system clock. A nil clock is silently
+// ignored.
+func WithClock(clock Clock) Option {
+	return func(o *Options) {
+		if clock != nil {
+			o.clock = clock
+		}
+	}
+}
+
+// WithRandSource sets the source of the random jitter added to the retry
+// backoff. Use it with a fixed seed in tests to assert exact wait sequences,
+// like a fake [Clock] set with [WithClock]. Access to src is serialised, so
+// it need not be safe for concurrent use, but it must not be used elsewhere
+// while the client uses it. The default is a securely seeded source. A nil
+// source is silently ignored.
+func WithRandSource(src rand.Source) Option {
+	return func(o *Options) {
+		if src != nil {
+			o.randSource = &lockedRandSource{src: src}
+		}
+	}
+}
+
+// WithAutoTimestamp controls whether alerts without a timestamp are stamped
+// with the send time, read from the client's [Clock]. Otherwise the server
+// stamps them on receipt, which skews timelines when sends are queued.
+// Explicitly set timestamps are never overwritten, and alerts are cloned so
+// the caller's values are not mutated. The default is false.
+func WithAutoTimestamp(enabled bool) Option {
+	return func(o *Options) {
+		o.autoTimestamp = enabled
+	}
+}
+
+// WithCertificatePin pins the server certificate to the given fingerprints,
+// each the hex-encoded SHA-256 digest of a DER certificate. Colons and
+// spaces are ignored, so fingerprints can be copied from openssl output.
+// Connections are rejected with [ErrCertificatePinMismatch] unless one of
+// the certificates in the chain presented by the server matches, which
+// defends against a compromised CA. Pinning runs after the regular
+// certificate verification and on every connection, including resumed
+// sessions. Pin the next certificate alongside the current one before
+// rotating it. Empty fingerprints are silently ignored; malformed ones are
+// rejected when [Client.Connect] is called. Multiple calls accumulate pins.
+func WithCertificatePin(sha256Fingerprints ...string) Option {
+	return func(o *Options) {
+		for _, fingerprint := range sha256Fingerprints {
+			fingerprint = normalizeFingerprint(strings.TrimSpace(fingerprint))
+			if fingerprint != "" {
+				o.certificatePins = append(o.certificatePins, fingerprint)
+			}
+		}
+	}
+}
+
+// WithTLSCipherSuites restricts the TLS 1.0-1.2 cipher suites the client
+// negotiates to ids, such as [tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256],
+// on top of the configuration set with [WithTLSConfig]. TLS 1.3 cipher
+// suites are not configurable in Go and are unaffected. Each ID must be one
+// of the secure suites listed by [tls.CipherSuites]; insecure and unknown
+// ones are rejected when [Client.Connect] is called. A later call replaces
+// the list of an earlier one. An empty list is silently ignored.
+func WithTLSCipherSuites(ids ...uint16) Option {
+	return func(o *Options) {
+		if len(ids) > 0 {
+			o.tlsCipherSuites = slices.Clone(ids)
+		}
+	}
+}
+
+// WithClientCertificateReloader sets the function that provides the client
+// certificate for mutual TLS. It is called on every TLS handshake, so that a
+// certificate rotated on disk is picked up by new connections without
+// reconnecting the client; pooled connections keep the certificate they
+// were established with. An error from fn fails the handshake. It takes
+// precedence over client certificates set with [WithTLSConfig]. The default
+// is nil. A nil function is silently ignored.
+func WithClientCertificateReloader(fn func() (tls.Certificate, error)) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.clientCertReloader = fn
+		}
+	}
+}
+
+// WithAuditWriter writes an audit trail of every request to w, one JSON
+// object per line with the time, method, URL, status, request headers,
+// request body and response body. Credentials are redacted from the URL and
+// from the Authorization, Proxy-Authorization and Cookie headers. Requests
+// that fail without a response are recorded with the error instead of a
+// status. Writes are serialised, so w need not be safe for concurrent use,
+// but a slow writer delays every request. A failed write is logged and does
+// not fail the request. A nil writer is silently ignored.
+func WithAuditWriter(w io.Writer) Option {
+	return func(o *Options) {
+		if w != nil {
+			o.auditWriter = w
+		}
+	}
+}
+
+// WithRetryOnBodyContains retries 2xx responses whose body contains any of
+// the given substrings, for servers that report overload with a success
+// status and a message such as "queue full, retry". Retries follow the
+// usual count and backoff settings. If the body still matches once retries
+// are exhausted, the send fails. Only 2xx responses are inspected; other
+// statuses are left to the retry policy. The check needs the whole response
+// body, which the client already buffers, so it costs one scan of each
+// successful body. Empty substrings are silently ignored. Multiple calls
+// accumulate substrings.
+func WithRetryOnBodyContains(substrings ...string) Option {
+	return func(o *Options) {
+		for _, substring := range substrings {
+			if substring != "" {
+				o.retryBodySubstrings = append(o.retryBodySubstrings, substring)
+			}
+		}
+	}
+}
+
+// WithMaxResponseHeaderBytes limits the size of the response headers the
+// client accepts, guarding against misbehaving servers or intermediaries
+// that send runaway headers. Responses exceeding the limit fail. The default
+// is 64 KB. Valid range is 4 KB–1 MB. Values outside this range are silently
+// ignored and the default is retained.
+func WithMaxResponseHeaderBytes(n int) Option {
+	return func(o *Options) {
+		if n >= minMaxResponseHeaderBytes && n <= maxMaxResponseHeaderBytes {
+			o.maxResponseHeaders = n
+		}
+	}
+}
+
+// WithProfile applies a curated bundle of options: [ProfileLowLatency],
+// [ProfileResilient] or [ProfileDevelopment]. The profile only fills in the
+// settings that the other options of the same [New] or [Options.Apply] call
+// leave alone, regardless of where it appears, so explicit options given
+// with it always override it. An unknown name is rejected when
+// [Client.Connect] is called. When given more than once, the last profile wins. Empty names are
+// silently ignored.
+func WithProfile(name string) Option {
+	return func(o *Options) {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			o.profile = name
+		}
+	}
+}
+
+// WithExpectedPingBody requires ping responses to contain the given
+// substring, e.g. `"status":"ok"`, in addition to a 2xx status. This catches
+// servers whose generic health endpoint answers 200 while the alert path is
+// unavailable, such as during read-only maintenance. It applies to the ping
+// made by [Client.Connect] and to [Client.Ping]. The default is empty, which
+// only checks the status.
+func WithExpectedPingBody(substring string) Option {
+	return func(o *Options) {
+		o.expectedPingBody = substring
+	}
+}
+
+// WithSharedTransport makes the client use t instead of building its own
+// transport, so that several clients talking to the same backend share one
+// connection pool and its limits. The caller owns t: configure its pool and
+// TLS settings directly, and close its idle connections once every client
+// using it is done; [Client.Close] leaves a shared transport untouched. The
+// per-client transport options ([WithMaxIdleConns], [WithMaxConnsPerHost],
+// [WithIdleConnTimeout], [WithDisableKeepAlive], [WithTLSConfig],
+// [WithTLSCipherSuites], [WithClientCertificateReloader],
+// [WithCertificatePin], [WithAcceptCompression],
+// [WithMaxResponseHeaderBytes], [WithPoolStats] and [WithConnMaxLifetime])
+// cannot be combined with it and are rejected when [Client.Connect] is
+// called. A nil transport is silently ignored.
+func WithSharedTransport(t *http.Transport) Option {
+	return func(o *Options) {
+		if t != nil {
+			o.sharedTransport = t
+		}
+	}
+}
+
+// WithLargeBatchWarning logs a warning through the [RequestLogger] whenever a
+// single send carries more than threshold alerts. Unexpectedly large batches
+// usually mean a backlog is building up upstream; the send itself proceeds
+// as usual. The default is 0, which disables the warning. Negative values
+// are silently ignored.
+func WithLargeBatchWarning(threshold int) Option {
+	return func(o *Options) {
+		if threshold >= 0 {
+			o.largeBatchThreshold = threshold
+		}
+	}
+}
+
+// WithDefaultRequestContext bounds every request made with a context that
+// has no deadline, such as context.Background(), to the given timeout,
+// including its retries. It is a safety net against requests blocking
+// indefinitely; contexts that already carry a deadline are left alone. The
+// default is 0, which leaves such requests bounded only by [WithTimeout]
+// per attempt. Negative values are silently ignored.
+func WithDefaultRequestContext(timeout time.Duration) Option {
+	return func(o *Options) {
+		if timeout >= 0 {
+			o.defaultRequestTimeout = timeout
+		}
+	}
+}
+
+// WithIndentedJSON controls whether request payloads are encoded as indented
+// JSON, two spaces per level, instead of compact JSON. It is a debugging
+// aid that makes captured payloads, e.g. from [WithAuditWriter], easier to
+// read. The payload stays valid JSON with the same structure, but is larger
+// on the wire. The default is false.
+func WithIndentedJSON(enabled bool) Option {
+	return func(o *Options) {
+		o.indentJSON = enabled
+	}
+}
+
+// WithStreamingEncode controls whether alert payloads are encoded while they
+// are sent instead of being marshaled up front. The encoder writes straight
+// into the request, so a large batch is never held in memory as one encoded
+// buffer, at the cost of encoding it again for every retry. The request is
+// sent with chunked transfer encoding, as its length is not known in
+// advance. It cannot be combined with options that need the encoded payload
+// before the request is made, [WithPayloadValidator], [WithMaxInFlightBytes]
+// and [WithNoRetryAboveBodySize], and payloads are missing from the records
+// of [WithAuditWriter]. The default is false.
+func WithStreamingEncode(enabled bool) Option {
+	return func(o *Options) {
+		o.streamingEncode = enabled
+	}
+}
+
+// WithMaxRetryAfter caps how long the client honours a server-provided
+// Retry-After header. A server asking for longer, e.g. a full day, is waited
+// for d instead. It can exceed [WithRetryMaxWaitTime], which keeps bounding
+// the client's own backoff. The default is 0, which caps Retry-After at the
+// retry max wait time. Valid range is 100ms–5 minutes. Values outside this
+// range are silently ignored.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(o *Options) {
+		if d >= minRetryMaxWaitTime && d <= maxRetryMaxWaitTime {
+			o.maxRetryAfter = d
+		}
+	}
+}
+
+// retryAfterCap returns the longest Retry-After wait the client honours.
+func (o *Options) retryAfterCap() time.Duration {
+	if o.maxRetryAfter > 0 {
+		return o.maxRetryAfter
+	}
+
+	return o.retryMaxWaitTime
+}
+
+// WithFieldEncryption encrypts the named alert fields with AES-GCM before
+// sending, for text that must be stored encrypted at rest by the server.
+// Fields are named by their JSON name, e.g. "text" or "footer", and must be
+// plain string fields of the alert. Each non-empty value is replaced by the
+// standard base64 encoding of the random nonce followed by the ciphertext,
+// and the X-Encrypted-Fields header lists the encrypted fields. Other fields
+// stay plaintext, and alerts are cloned so the caller's values are not
+// mutated. key must be 16, 24 or 32 bytes long, selecting AES-128, AES-192
+// or AES-256; the key and field names are validated when [Client.Connect]
+// is called. Calling it with no fields is silently ignored.
+func WithFieldEncryption(key []byte, fields ...string) Option {
+	return func(o *Options) {
+		var names []string
+
+		for _, field := range fields {
+			if field = strings.TrimSpace(field); field != "" && !slices.Contains(names, field) {
+				names = append(names, field)
+			}
+		}
+
+		if len(names) > 0 {
+			o.encryptionKey = bytes.Clone(key)
+			o.encryptedFields = names
+		}
+	}
+}
+
+// WithDialNetwork forces the network used to dial the API: "tcp4" for IPv4
+// only, "tcp6" for IPv6 only, or "tcp" (the default) to let Go pick between
+// the resolved addresses. This works around a broken IPv4 or IPv6 path on
+// dual-stack hosts without changing the host configuration. Forcing a
+// network installs a custom dialer, with which net/http does not attempt
+// HTTP/2. Other values are silently ignored.
+func WithDialNetwork(network string) Option {
+	return func(o *Options) {
+		if isSupportedDialNetwork(network) {
+			o.dialNetwork = network
+		}
+	}
+}
+
+func isSupportedDialNetwork(network string) bool {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		return true
+	default:
+		return false
+	}
+}
+
+// WithStatusLogging logs the final response of every request through the
+// [RequestLogger], with its method, sanitized URL and status code. The level
+// depends on the status family: debug for 1xx to 3xx, warn for 4xx and error
+// for 5xx, so that log-based alerting can fire on server errors only. Use
+// [WithStatusLogLevels] to change the levels. Status logging is disabled by
+// default.
+func WithStatusLogging(enabled bool) Option {
+	return func(o *Options) {
+		o.statusLogging = enabled
+	}
+}
+
+// WithStatusLogLevels overrides the levels used by [WithStatusLogging] for
+// the given status families, keyed by family (2 for 2xx, 5 for 5xx, and so
+// on). Levels are [LogLevelDebug], [LogLevelWarn], [LogLevelError] and
+// [LogLevelOff]. Families not in levels keep their default. It does not
+// enable status logging by itself. Entries with a family outside 1 to 5 or an
+// unknown level are silently ignored.
+func WithStatusLogLevels(levels map[int]string) Option {
+	return func(o *Options) {
+		for family, level := range levels {
+			if family >= 1 && family <= 5 && isValidLogLevel(level) {
+				o.statusLogLevels[family] = level
+			}
+		}
+	}
+}
+
+// WithNoRetryAboveBodySize disables retries for requests whose body is larger
+// than size bytes, so that a giant batch fails fast instead of being replayed
+// while the caller waits. Smaller requests retry as usual, and a debug
+// message is logged whenever a retry is skipped because of the body size.
+// The default is 0, meaning requests are retried whatever their size.
+// Negative values are silently ignored.
+func WithNoRetryAboveBodySize(size int) Option {
+	return func(o *Options) {
+		if size >= 0 {
+			o.noRetryAboveBodySize = size
+		}
+	}
+}
+
+// WithResponseTimeoutRetry controls whether sends whose attempt timed out
+// are retried, on top of what the retry policy asks for. A send that timed
+// out after the request was written, while waiting for the response, may
+// already have been processed by the API, so retrying it can post the
+// alerts twice; enable this only when the API deduplicates them, for
+// instance by correlation ID. When disabled, such a send is never retried,
+// whatever the retry policy says, and a send that timed out before the
+// request was written, such as while connecting, is left to the retry
+// policy, which [DefaultRetryPolicy] does not retry. The default is false.
+func WithResponseTimeoutRetry(enabled bool) Option {
+	return func(o *Options) {
+		o.responseTimeoutRetry = enabled
+	}
+}
+
+// WithObserver registers o to receive callbacks for the lifecycle of every
+// send: its start, each retried attempt, and its success or failure. It is a
+// single extension point for the events otherwise spread over hook options
+// such as [WithTimingCallback] and [WithStatusFamilyCallback]. A nil o is
+// silently ignored.
+func WithObserver(o SendObserver) Option {
+	return func(opts *Options) {
+		if o != nil {
+			opts.observer = o
+		}
+	}
+}
+
+// WithValidateConnOnUse makes the client replace a pooled connection that has
+// been idle for more than 10 seconds with a fresh one before sending on it.
+// Behind a load balancer, such a connection may point at a terminated
+// backend, and the first request sent on it fails. The cost is a new dial,
+// and TLS handshake when applicable, for the first request after an idle
+// period, typically a few milliseconds within a data centre. Disabled by
+// default.
+func WithValidateConnOnUse(enabled bool) Option {
+	return func(o *Options) {
+		o.validateConnOnUse = enabled
+	}
+}
+
+// WithRedirectPolicy sets a function that decides whether to follow a
+// redirect to req, via holding the requests made so far, oldest first.
+// Returning an error stops the redirect and fails the request with that
+// error. The limit set with [WithMaxRedirects] still applies. Use
+// [SameHostRedirectPolicy] to reject redirects to other hosts. The default is
+// to follow any redirect within the limit. A nil fn is silently ignored.
+func WithRedirectPolicy(fn func(req *http.Request, via []*http.Request) error) Option {
+	return func(o *Options) {
+		if fn != nil {
+			o.redirectPolicy = fn
+		}
+	}
+}
+
+// WithPreferredAPIVersion enables API version negotiation. [Client.Connect]
+// reads the versions the server supports from the ping response, such as
+// {"supported_versions":["v1","v2"]}, and sends alerts to the endpoints of
+// version, e.g. "v2/alerts", if the server supports it. Otherwise the highest
+// supported version below it is used, or the unversioned endpoints if there
+// is none, and a warning is logged; see [WithStrictVersioning] to fail
+// instead. [Client.APIVersion] returns the negotiated version. By default
+// there is no negotiation and the unversioned endpoints are used. Versions
+// not of the form "v1", "v2" and so on are silently ignored.
+func WithPreferredAPIVersion(version string) Option {
+	return func(o *Options) {
+		if apiVersionPattern.MatchString(version) {
+			o.preferredAPIVersion = version
+		}
+	}
+}
+
+// WithStrictVersioning turns version mismatches into errors: [Client.Connect]
+// fails when the server does not support the version set with
+// [WithPreferredAPIVersion], instead of falling back to an older version,
+// and a send fails when the response version differs from the one set with
+// [WithExpectedResponseVersion], instead of logging a warning. Disabled by
+// default.
+func WithStrictVersioning(strict bool) Option {
+	return func(o *Options) {
+		o.strictVersioning = strict
+	}
+}
+
+// WithAdaptiveThrottle spaces sends out while the server is struggling. Each
+// send answered with a 429 or a 5xx status doubles the minimum delay between
+// sends, starting at baseDelay and capped at maxDelay, and each other
+// response reduces it by baseDelay, back down to no delay. Sends that get no
+// response, such as cancelled sends or dial errors, leave it unchanged. This
+// eases the load on a failing server more gradually than failing fast. The
+// delay applies before every POST, on top of the retries of a single send.
+// Disabled by default. Calls with a non-positive baseDelay or a maxDelay
+// below baseDelay are silently ignored.
+func WithAdaptiveThrottle(baseDelay, maxDelay time.Duration) Option {
+	return func(o *Options) {
+		if baseDelay > 0 && maxDelay >= baseDelay {
+			o.throttleBaseDelay = baseDelay
+			o.throttleMaxDelay = maxDelay
+		}
+	}
+}
+
+// WithExpectedResponseVersion sets the API version that responses to sends
+// are expected to report in their X-API-Version header. A response reporting
+// another version is logged as a warning, so that a server upgrade changing
+// the response shape is noticed immediately; with [WithStrictVersioning] the
+// send fails with [ErrResponseVersionMismatch] instead. Responses without the
+// header are not checked. By default no version is expected. The value is
+// trimmed; empty values are silently ignored.
+func WithExpectedResponseVersion(version string) Option {
+	return func(o *Options) {
+		if version = strings.TrimSpace(version); version != "" {
+			o.expectedResponseVersion = version
+		}
+	}
+}
+
+// WithMaxInFlightBytes limits the total size of the request bodies being sent
+// at once across all concurrent sends to n bytes. A send that would exceed
+// the limit waits, while respecting its context, until earlier sends
+// complete, whether they succeed, fail or are cancelled. Waiting sends are
+// admitted in order; a body larger than n is sent once nothing else is in
+// flight. This bounds the memory held by request bodies under load. The
+// default is 0, meaning no limit. Negative values are silently ignored.
+func WithMaxInFlightBytes(n int64) Option {
+	return func(o *Options) {
+		if n >= 0 {
+			o.maxInFlightBytes = n
+		}
+	}
+}
+
+// WithDrainContext ties the client to a shutdown context, such as one
+// cancelled on SIGTERM. Once ctx is done the client is draining: new sends
+// fail with [ErrDraining], while sends already in flight complete normally.
+// The client has no send buffer, so there is nothing else to flush; combine
+// with [Client.CloseWithGrace] to wait for the sends in flight. A nil ctx is
+// silently ignored.
+func WithDrainContext(ctx context.Context) Option {
+	return func(o *Options) {
+		if ctx != nil {
+			o.drainCtx = ctx
+		}
+	}
+}
+
+// WithMaxLabelsPerAlert limits the number of labels of each alert to n,
+// protecting the server from a cardinality explosion caused by a bug that
+// attaches many unique labels. As [types.Alert] has no dedicated labels
+// field, labels are the entries of its Metadata map, including those added
+// by [WithGlobalLabels]. A send containing an alert over the limit fails
+// with an error naming the alert, unless [WithTruncateExcessLabels] is
+// enabled. The default is 0, meaning no limit. Negative values are silently
+// ignored.
+func WithMaxLabelsPerAlert(n int) Option {
+	return func(o *Options) {
+		if n >= 0 {
+			o.maxLabelsPerAlert = n
+		}
+	}
+}
+
+// WithTruncateExcessLabels makes alerts over the [WithMaxLabelsPerAlert]
+// limit keep their first labels in key order and drop the rest, logging a
+// warning, instead of failing the send. The caller's alerts are not
+// modified. Disabled by default.
+func WithTruncateExcessLabels(enabled bool) Option {
+	return func(o *Options) {
+		o.truncateExcessLabels = enabled
+	}
+}
+
 // Validate checks all options fields for validity and returns an error if any are invalid.
 func (o *Options) Validate() error {
 	if o.retryCount < 0 {
@@ -392,5 +1587,238 @@ func (o *Options) Validate() error {
 		return errors.New("pingEndpoint must not be empty")
 	}
 
+	if o.maxSendDuration < 0 {
+		return errors.New("maxSendDuration must be non-negative")
+	}
+
+	if o.resolveParam == "" || o.resolveValue == "" {
+		return errors.New("resolveParam and resolveValue must not be empty")
+	}
+
+	if o.errorMessagePath == "" {
+		return errors.New("errorMessagePath must not be empty")
+	}
+
+	if o.rateLimit < 0 {
+		return errors.New("rateLimit must be non-negative")
+	}
+
+	if o.rateLimit > 0 && o.rateLimitBurst < 1 {
+		return errors.New("rateLimitBurst must be at least 1")
+	}
+
+	if o.pingTolerance < 0 {
+		return errors.New("pingTolerance must be non-negative")
+	}
+
+	if o.pingTolerance > maxPingTolerance {
+		return fmt.Errorf("pingTolerance must not exceed %d", maxPingTolerance)
+	}
+
+	if o.pingTolerance > 0 && o.pingInterval <= 0 {
+		return errors.New("pingInterval must be positive when pingTolerance is set")
+	}
+
+	if o.maxRetryAfter != 0 && (o.maxRetryAfter < minRetryMaxWaitTime || o.maxRetryAfter > maxRetryMaxWaitTime) {
+		return fmt.Errorf("maxRetryAfter must be between %v and %v", minRetryMaxWaitTime, maxRetryMaxWaitTime)
+	}
+
+	if len(o.encryptedFields) > 0 {
+		switch len(o.encryptionKey) {
+		case 16, 24, 32:
+		default:
+			return fmt.Errorf("encryption key must be 16, 24 or 32 bytes, got %d", len(o.encryptionKey))
+		}
+
+		known := encryptableFields()
+
+		for _, field := range o.encryptedFields {
+			if _, ok := known[field]; !ok {
+				return fmt.Errorf("cannot encrypt %q: not a string field of the alert", field)
+			}
+		}
+	}
+
+	if o.defaultRequestTimeout < 0 {
+		return errors.New("defaultRequestTimeout must be non-negative")
+	}
+
+	if o.largeBatchThreshold < 0 {
+		return errors.New("largeBatchThreshold must be non-negative")
+	}
+
+	if tuned := o.transportTuning(); o.sharedTransport != nil && len(tuned) > 0 {
+		return fmt.Errorf("sharedTransport cannot be combined with per-client transport options: %s", strings.Join(tuned, ", "))
+	}
+
+	if _, ok := profileOptions(o.profile); o.profile != "" && !ok {
+		return fmt.Errorf("unknown profile %q", o.profile)
+	}
+
+	if o.maxResponseHeaders < minMaxResponseHeaderBytes || o.maxResponseHeaders > maxMaxResponseHeaderBytes {
+		return fmt.Errorf("maxResponseHeaders must be between %d and %d", minMaxResponseHeaderBytes, maxMaxResponseHeaderBytes)
+	}
+
+	if o.connMaxLifetime < 0 {
+		return errors.New("connMaxLifetime must be non-negative")
+	}
+
+	if o.maxLabelsPerAlert < 0 {
+		return errors.New("maxLabelsPerAlert must be non-negative")
+	}
+
+	if o.maxInFlightBytes < 0 {
+		return errors.New("maxInFlightBytes must be non-negative")
+	}
+
+	if o.throttleBaseDelay < 0 || o.throttleMaxDelay < o.throttleBaseDelay {
+		return errors.New("adaptive throttle delays must be non-negative, with maxDelay not below baseDelay")
+	}
+
+	if o.preferredAPIVersion != "" && !apiVersionPattern.MatchString(o.preferredAPIVersion) {
+		return fmt.Errorf("invalid preferred API version %q: must be of the form v1, v2, ...", o.preferredAPIVersion)
+	}
+
+	if o.noRetryAboveBodySize < 0 {
+		return errors.New("noRetryAboveBodySize must be non-negative")
+	}
+
+	if o.connectJitter < 0 {
+		return errors.New("connectJitter must be non-negative")
+	}
+
+	if !o.retryMode.valid() {
+		return fmt.Errorf("invalid retry mode %v", o.retryMode)
+	}
+
+	for family, level := range o.statusLogLevels {
+		if family < 1 || family > 5 || !isValidLogLevel(level) {
+			return fmt.Errorf("invalid status log level %q for status family %d", level, family)
+		}
+	}
+
+	if !isSupportedDialNetwork(o.dialNetwork) {
+		return fmt.Errorf("unsupported dial network %q: must be tcp, tcp4 or tcp6", o.dialNetwork)
+	}
+
+	for _, fingerprint := range o.certificatePins {
+		if !isValidFingerprint(fingerprint) {
+			return fmt.Errorf("invalid certificate pin %q: must be a hex-encoded SHA-256 fingerprint", fingerprint)
+		}
+	}
+
+	for _, id := range o.tlsCipherSuites {
+		if err := checkCipherSuite(id); err != nil {
+			return fmt.Errorf("invalid TLS cipher suite: %w", err)
+		}
+	}
+
+	for _, probe := range o.connectProbes {
+		switch probe.method {
+		case resty.MethodGet, resty.MethodHead, resty.MethodOptions:
+		default:
+			return fmt.Errorf("unsupported connect probe method %q", probe.method)
+		}
+	}
+
+	if o.minSeverity != "" && (!types.SeverityIsValid(o.minSeverity) || o.minSeverity == types.AlertResolved) {
+		return fmt.Errorf("invalid minimum severity %q: must be info, warning, error or panic", o.minSeverity)
+	}
+
+	switch o.connectFallback.method {
+	case "", resty.MethodGet, resty.MethodHead, resty.MethodOptions:
+	default:
+		return fmt.Errorf("unsupported connect fallback method %q", o.connectFallback.method)
+	}
+
+	if encoded := o.encodedPayloadOptions(); o.streamingEncode && len(encoded) > 0 {
+		return fmt.Errorf("streamingEncode cannot be combined with options that need the encoded payload: %s", strings.Join(encoded, ", "))
+	}
+
 	return nil
 }
+
+// transportTuning returns the names of the per-client transport options that
+// differ from their defaults.
+func (o *Options) transportTuning() []string {
+	var tuned []string
+
+	if o.maxIdleConns != defaultMaxIdleConns {
+		tuned = append(tuned, "maxIdleConns")
+	}
+
+	if o.maxConnsPerHost != defaultMaxConnsPerHost {
+		tuned = append(tuned, "maxConnsPerHost")
+	}
+
+	if o.idleConnTimeout != defaultIdleConnTimeout {
+		tuned = append(tuned, "idleConnTimeout")
+	}
+
+	if o.disableKeepAlive {
+		tuned = append(tuned, "disableKeepAlive")
+	}
+
+	if o.tlsConfig != nil {
+		tuned = append(tuned, "tlsConfig")
+	}
+
+	if len(o.certificatePins) > 0 {
+		tuned = append(tuned, "certificatePins")
+	}
+
+	if len(o.tlsCipherSuites) > 0 {
+		tuned = append(tuned, "tlsCipherSuites")
+	}
+
+	if o.clientCertReloader != nil {
+		tuned = append(tuned, "clientCertReloader")
+	}
+
+	if !o.acceptCompression {
+		tuned = append(tuned, "acceptCompression")
+	}
+
+	if o.maxResponseHeaders != defaultMaxResponseHeaderBytes {
+		tuned = append(tuned, "maxResponseHeaders")
+	}
+
+	if o.poolStats {
+		tuned = append(tuned, "poolStats")
+	}
+
+	if o.connMaxLifetime > 0 {
+		tuned = append(tuned, "connMaxLifetime")
+	}
+
+	if o.dialNetwork != defaultDialNetwork {
+		tuned = append(tuned, "dialNetwork")
+	}
+
+	if o.validateConnOnUse {
+		tuned = append(tuned, "validateConnOnUse")
+	}
+
+	return tuned
+}
+
+// encodedPayloadOptions returns the names of the options that need the
+// encoded payload before the request is made, which [WithStreamingEncode]
+// does not provide.
+func (o *Options) encodedPayloadOptions() []string {
+	var names []string
+
+	if o.payloadValidator != nil {
+		names = append(names, "payloadValidator")
+	}
+
+	if o.maxInFlightBytes > 0 {
+		names = append(names, "maxInFlightBytes")
+	}
+
+	if o.noRetryAboveBodySize > 0 {
+		names = append(names, "noRetryAboveBodySize")
+	}
+
+	return names
+}
@@ -1,11 +1,18 @@
 package client
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
 )
 
 func TestNewClientOptions(t *testing.T) {
@@ -84,6 +91,18 @@ func TestNewClientOptions(t *testing.T) {
 	if opts.tlsConfig != nil {
 		t.Errorf("expected tlsConfig=nil, got %v", opts.tlsConfig)
 	}
+
+	if opts.resolveParam != "state" || opts.resolveValue != "resolved" {
+		t.Errorf("expected resolve marker state=resolved, got %s=%s", opts.resolveParam, opts.resolveValue)
+	}
+
+	if !opts.acceptCompression {
+		t.Error("expected acceptCompression=true")
+	}
+
+	if opts.maxResponseHeaders != 64<<10 {
+		t.Errorf("expected maxResponseHeaders=65536, got %d", opts.maxResponseHeaders)
+	}
 }
 
 func TestWithRetryCount(t *testing.T) {
@@ -641,6 +660,193 @@ func TestOptionsValidate(t *testing.T) {
 			modify:    func(o *Options) { o.pingEndpoint = "" },
 			wantError: "pingEndpoint must not be empty",
 		},
+		{
+			name:      "negative maxSendDuration",
+			modify:    func(o *Options) { o.maxSendDuration = -1 },
+			wantError: "maxSendDuration must be non-negative",
+		},
+		{
+			name:      "empty resolveParam",
+			modify:    func(o *Options) { o.resolveParam = "" },
+			wantError: "resolveParam and resolveValue must not be empty",
+		},
+		{
+			name:      "empty errorMessagePath",
+			modify:    func(o *Options) { o.errorMessagePath = "" },
+			wantError: "errorMessagePath must not be empty",
+		},
+		{
+			name:      "negative rateLimit",
+			modify:    func(o *Options) { o.rateLimit = -1 },
+			wantError: "rateLimit must be non-negative",
+		},
+		{
+			name:      "rateLimit without burst",
+			modify:    func(o *Options) { o.rateLimit = 10 },
+			wantError: "rateLimitBurst must be at least 1",
+		},
+		{
+			name:      "negative pingTolerance",
+			modify:    func(o *Options) { o.pingTolerance = -1 },
+			wantError: "pingTolerance must be non-negative",
+		},
+		{
+			name:      "pingTolerance exceeds max",
+			modify:    func(o *Options) { o.pingTolerance = 101 },
+			wantError: "pingTolerance must not exceed 100",
+		},
+		{
+			name:      "pingTolerance without interval",
+			modify:    func(o *Options) { o.pingTolerance = 1 },
+			wantError: "pingInterval must be positive when pingTolerance is set",
+		},
+		{
+			name: "sharedTransport with transport tuning",
+			modify: func(o *Options) {
+				o.sharedTransport = &http.Transport{}
+				o.maxConnsPerHost = 20
+				o.poolStats = true
+			},
+			wantError: "sharedTransport cannot be combined with per-client transport options: maxConnsPerHost, poolStats",
+		},
+		{
+			name:      "maxRetryAfter out of range",
+			modify:    func(o *Options) { o.maxRetryAfter = time.Hour },
+			wantError: "maxRetryAfter must be between 100ms and 5m0s",
+		},
+		{
+			name:      "negative maxLabelsPerAlert",
+			modify:    func(o *Options) { o.maxLabelsPerAlert = -1 },
+			wantError: "maxLabelsPerAlert must be non-negative",
+		},
+		{
+			name:      "negative maxInFlightBytes",
+			modify:    func(o *Options) { o.maxInFlightBytes = -1 },
+			wantError: "maxInFlightBytes must be non-negative",
+		},
+		{
+			name: "adaptive throttle maxDelay below baseDelay",
+			modify: func(o *Options) {
+				o.throttleBaseDelay = time.Second
+				o.throttleMaxDelay = time.Millisecond
+			},
+			wantError: "adaptive throttle delays must be non-negative, with maxDelay not below baseDelay",
+		},
+		{
+			name:      "invalid preferred API version",
+			modify:    func(o *Options) { o.preferredAPIVersion = "2" },
+			wantError: `invalid preferred API version "2": must be of the form v1, v2, ...`,
+		},
+		{
+			name:      "negative noRetryAboveBodySize",
+			modify:    func(o *Options) { o.noRetryAboveBodySize = -1 },
+			wantError: "noRetryAboveBodySize must be non-negative",
+		},
+		{
+			name:      "invalid status log level",
+			modify:    func(o *Options) { o.statusLogLevels[5] = "fatal" },
+			wantError: `invalid status log level "fatal" for status family 5`,
+		},
+		{
+			name:      "unsupported dialNetwork",
+			modify:    func(o *Options) { o.dialNetwork = "udp" },
+			wantError: `unsupported dial network "udp": must be tcp, tcp4 or tcp6`,
+		},
+		{
+			name: "invalid encryption key length",
+			modify: func(o *Options) {
+				o.encryptionKey = []byte("short")
+				o.encryptedFields = []string{"text"}
+			},
+			wantError: "encryption key must be 16, 24 or 32 bytes, got 5",
+		},
+		{
+			name: "unknown encrypted field",
+			modify: func(o *Options) {
+				o.encryptionKey = make([]byte, 16)
+				o.encryptedFields = []string{"severity"}
+			},
+			wantError: `cannot encrypt "severity": not a string field of the alert`,
+		},
+		{
+			name:      "negative defaultRequestTimeout",
+			modify:    func(o *Options) { o.defaultRequestTimeout = -1 },
+			wantError: "defaultRequestTimeout must be non-negative",
+		},
+		{
+			name:      "negative largeBatchThreshold",
+			modify:    func(o *Options) { o.largeBatchThreshold = -1 },
+			wantError: "largeBatchThreshold must be non-negative",
+		},
+		{
+			name:      "unknown profile",
+			modify:    func(o *Options) { o.profile = "turbo" },
+			wantError: `unknown profile "turbo"`,
+		},
+		{
+			name:      "maxResponseHeaders too small",
+			modify:    func(o *Options) { o.maxResponseHeaders = 1024 },
+			wantError: "maxResponseHeaders must be between 4096 and 1048576",
+		},
+		{
+			name:      "maxResponseHeaders too large",
+			modify:    func(o *Options) { o.maxResponseHeaders = 2 << 20 },
+			wantError: "maxResponseHeaders must be between 4096 and 1048576",
+		},
+		{
+			name:      "negative connMaxLifetime",
+			modify:    func(o *Options) { o.connMaxLifetime = -1 },
+			wantError: "connMaxLifetime must be non-negative",
+		},
+		{
+			name:      "invalid certificate pin",
+			modify:    func(o *Options) { o.certificatePins = []string{"abc"} },
+			wantError: `invalid certificate pin "abc": must be a hex-encoded SHA-256 fingerprint`,
+		},
+		{
+			name:      "insecure TLS cipher suite",
+			modify:    func(o *Options) { o.tlsCipherSuites = []uint16{tls.TLS_RSA_WITH_RC4_128_SHA} },
+			wantError: "invalid TLS cipher suite: cipher suite TLS_RSA_WITH_RC4_128_SHA is insecure",
+		},
+		{
+			name:      "unknown TLS cipher suite",
+			modify:    func(o *Options) { o.tlsCipherSuites = []uint16{0x1234} },
+			wantError: "invalid TLS cipher suite: unknown cipher suite 0x1234",
+		},
+		{
+			name:      "unsupported connect probe method",
+			modify:    func(o *Options) { o.connectProbes = []connectProbe{{method: "POST", path: "alerts"}} },
+			wantError: `unsupported connect probe method "POST"`,
+		},
+		{
+			name:      "invalid minimum severity",
+			modify:    func(o *Options) { o.minSeverity = "debug" },
+			wantError: `invalid minimum severity "debug": must be info, warning, error or panic`,
+		},
+		{
+			name:      "unsupported connect fallback method",
+			modify:    func(o *Options) { o.connectFallback = connectProbe{method: "DELETE", path: "alerts"} },
+			wantError: `unsupported connect fallback method "DELETE"`,
+		},
+		{
+			name:      "invalid retry mode",
+			modify:    func(o *Options) { o.retryMode = RetryMode(5) },
+			wantError: "invalid retry mode RetryMode(5)",
+		},
+		{
+			name:      "negative connect jitter",
+			modify:    func(o *Options) { o.connectJitter = -time.Second },
+			wantError: "connectJitter must be non-negative",
+		},
+		{
+			name: "streaming encode with encoded payload options",
+			modify: func(o *Options) {
+				o.streamingEncode = true
+				o.maxInFlightBytes = 1 << 20
+				o.noRetryAboveBodySize = 1 << 20
+			},
+			wantError: "streamingEncode cannot be combined with options that need the encoded payload: maxInFlightBytes, noRetryAboveBodySize",
+		},
 	}
 
 	for _, tt := range tests {
@@ -764,3 +970,1414 @@ func TestWithRequestHeader_ValueTrimmed(t *testing.T) {
 		t.Errorf("expected trimmed value, got %q", opts.requestHeaders["X-Custom"])
 	}
 }
+
+func TestDefaultOptions(t *testing.T) {
+	t.Parallel()
+
+	opts := DefaultOptions()
+
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("expected default options to be valid, got %v", err)
+	}
+
+	if opts.retryCount != 3 {
+		t.Errorf("expected retryCount=3, got %d", opts.retryCount)
+	}
+
+	if opts.authToken != "" || opts.basicAuthUsername != "" || opts.basicAuthPassword != "" {
+		t.Error("expected credentials to be empty")
+	}
+
+	// Each call must return an independent copy
+	other := DefaultOptions()
+	other.requestHeaders["X-Other"] = "value"
+
+	if _, ok := opts.requestHeaders["X-Other"]; ok {
+		t.Error("expected DefaultOptions to return independent copies")
+	}
+}
+
+func TestOptionsApply(t *testing.T) {
+	t.Parallel()
+
+	opts := DefaultOptions()
+	result := opts.Apply(WithRetryCount(7), WithUserAgent("wrapper/1.0"))
+
+	if result != opts {
+		t.Error("expected Apply to return the receiver")
+	}
+
+	if opts.retryCount != 7 {
+		t.Errorf("expected retryCount=7, got %d", opts.retryCount)
+	}
+
+	if opts.userAgent != "wrapper/1.0" {
+		t.Errorf("expected userAgent=wrapper/1.0, got %s", opts.userAgent)
+	}
+}
+
+func TestOptionsAccessors(t *testing.T) {
+	t.Parallel()
+
+	opts := DefaultOptions().Apply(
+		WithRetryCount(7),
+		WithRetryWaitTime(2*time.Second),
+		WithRetryMaxWaitTime(20*time.Second),
+		WithTimeout(45*time.Second),
+		WithProfile(ProfileResilient),
+	)
+
+	if opts.RetryCount() != 7 {
+		t.Errorf("expected RetryCount=7, got %d", opts.RetryCount())
+	}
+
+	if opts.RetryWaitTime() != 2*time.Second {
+		t.Errorf("expected RetryWaitTime=2s, got %v", opts.RetryWaitTime())
+	}
+
+	if opts.RetryMaxWaitTime() != 20*time.Second {
+		t.Errorf("expected RetryMaxWaitTime=20s, got %v", opts.RetryMaxWaitTime())
+	}
+
+	if opts.Timeout() != 45*time.Second {
+		t.Errorf("expected Timeout=45s, got %v", opts.Timeout())
+	}
+
+	if opts.Profile() != ProfileResilient {
+		t.Errorf("expected Profile=%s, got %q", ProfileResilient, opts.Profile())
+	}
+}
+
+func TestWithSeverityEndpoint(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithSeverityEndpoint("panic", "critical-alerts")(opts)
+	WithSeverityEndpoint("  info  ", "  info-alerts  ")(opts)
+	WithSeverityEndpoint("", "ignored")(opts)
+	WithSeverityEndpoint("warning", "   ")(opts)
+
+	if len(opts.severityEndpoints) != 2 {
+		t.Fatalf("expected 2 severity endpoints, got %d", len(opts.severityEndpoints))
+	}
+
+	if opts.severityEndpoints["panic"] != "critical-alerts" {
+		t.Errorf("expected panic=critical-alerts, got %s", opts.severityEndpoints["panic"])
+	}
+
+	if opts.severityEndpoints["info"] != "info-alerts" {
+		t.Errorf("expected info=info-alerts, got %s", opts.severityEndpoints["info"])
+	}
+}
+
+func TestWithTimingCallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid callback", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newClientOptions()
+		WithTimingCallback(func(context.Context, RequestTimings) {})(opts)
+
+		if opts.timingCallback == nil {
+			t.Error("expected timingCallback to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newClientOptions()
+		WithTimingCallback(nil)(opts)
+
+		if opts.timingCallback != nil {
+			t.Error("nil callback should be ignored")
+		}
+	})
+}
+
+func TestWithTreatBodyErrorsAsFailure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid function", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newClientOptions()
+		WithTreatBodyErrorsAsFailure(func(context.Context, []byte) error { return nil })(opts)
+
+		if opts.bodyErrorCheck == nil {
+			t.Error("expected bodyErrorCheck to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newClientOptions()
+		WithTreatBodyErrorsAsFailure(nil)(opts)
+
+		if opts.bodyErrorCheck != nil {
+			t.Error("nil function should be ignored")
+		}
+	})
+}
+
+func TestWithMaxSendDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected time.Duration
+	}{
+		{"valid", 10 * time.Second, 10 * time.Second},
+		{"zero ignored", 0, 0},
+		{"negative ignored", -time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithMaxSendDuration(tt.input)(opts)
+
+			if opts.maxSendDuration != tt.expected {
+				t.Errorf("expected maxSendDuration=%v, got %v", tt.expected, opts.maxSendDuration)
+			}
+		})
+	}
+}
+
+func TestWithResolveQueryParam(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		param, value  string
+		expectedParam string
+		expectedValue string
+	}{
+		{"valid", "status", "closed", "status", "closed"},
+		{"whitespace trimmed", "  status  ", "  closed  ", "status", "closed"},
+		{"empty name ignored", "", "closed", "state", "resolved"},
+		{"empty value ignored", "status", "  ", "state", "resolved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithResolveQueryParam(tt.param, tt.value)(opts)
+
+			if opts.resolveParam != tt.expectedParam || opts.resolveValue != tt.expectedValue {
+				t.Errorf("expected %s=%s, got %s=%s", tt.expectedParam, tt.expectedValue, opts.resolveParam, opts.resolveValue)
+			}
+		})
+	}
+}
+
+func TestWithGlobalLabels(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithGlobalLabels(map[string]string{"service": "checkout", "  ": "ignored"})(opts)
+	WithGlobalLabels(map[string]string{" region ": "eu"})(opts)
+
+	if len(opts.globalLabels) != 2 {
+		t.Fatalf("expected 2 global labels, got %v", opts.globalLabels)
+	}
+
+	if opts.globalLabels["service"] != "checkout" || opts.globalLabels["region"] != "eu" {
+		t.Errorf("unexpected global labels: %v", opts.globalLabels)
+	}
+}
+
+func TestWithEnvelopeMetadata(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+
+	if opts.envelopeMetadata != nil {
+		t.Fatal("expected no envelope metadata by default")
+	}
+
+	WithEnvelopeMetadata(nil)(opts)
+
+	if opts.envelopeMetadata == nil || len(opts.envelopeMetadata) != 0 {
+		t.Fatalf("expected empty envelope metadata to be enabled, got %v", opts.envelopeMetadata)
+	}
+
+	WithEnvelopeMetadata(map[string]string{"team": "payments", "  ": "ignored"})(opts)
+	WithEnvelopeMetadata(map[string]string{" job ": "import"})(opts)
+
+	if len(opts.envelopeMetadata) != 2 || opts.envelopeMetadata["team"] != "payments" || opts.envelopeMetadata["job"] != "import" {
+		t.Errorf("unexpected envelope metadata: %v", opts.envelopeMetadata)
+	}
+}
+
+func TestWithErrorMessagePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"valid path", "data.error.message", "data.error.message"},
+		{"empty ignored", "", "error"},
+		{"whitespace ignored", "   ", "error"},
+		{"whitespace trimmed", "  message  ", "message"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithErrorMessagePath(tt.input)(opts)
+
+			if opts.errorMessagePath != tt.expected {
+				t.Errorf("expected errorMessagePath=%s, got %s", tt.expected, opts.errorMessagePath)
+			}
+		})
+	}
+}
+
+func TestWithPoolStats(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithPoolStats(true)(opts)
+
+	if !opts.poolStats {
+		t.Error("expected poolStats=true")
+	}
+
+	WithPoolStats(false)(opts)
+
+	if opts.poolStats {
+		t.Error("expected poolStats=false")
+	}
+}
+
+func TestWithMiddleware(t *testing.T) {
+	t.Parallel()
+
+	passThrough := func(next SendFunc) SendFunc { return next }
+
+	opts := newClientOptions()
+	WithMiddleware(passThrough, nil)(opts)
+	WithMiddleware(passThrough)(opts)
+
+	if len(opts.middleware) != 2 {
+		t.Errorf("expected 2 middleware, got %d", len(opts.middleware))
+	}
+}
+
+func TestWithStatusFamilyCallback(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithStatusFamilyCallback(nil)(opts)
+
+	if opts.statusFamilyFn != nil {
+		t.Error("nil callback should be ignored")
+	}
+
+	WithStatusFamilyCallback(func(context.Context, int) {})(opts)
+
+	if opts.statusFamilyFn == nil {
+		t.Error("expected statusFamilyFn to be set")
+	}
+}
+
+func TestWithPerHostRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		rate          float64
+		burst         int
+		expectedRate  float64
+		expectedBurst int
+	}{
+		{"valid", 10, 5, 10, 5},
+		{"zero rate ignored", 0, 5, 0, 0},
+		{"negative rate ignored", -1, 5, 0, 0},
+		{"zero burst ignored", 10, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithPerHostRateLimit(tt.rate, tt.burst)(opts)
+
+			if opts.rateLimit != tt.expectedRate || opts.rateLimitBurst != tt.expectedBurst {
+				t.Errorf("expected rate=%v burst=%d, got rate=%v burst=%d", tt.expectedRate, tt.expectedBurst, opts.rateLimit, opts.rateLimitBurst)
+			}
+		})
+	}
+}
+
+func TestWithSortAlerts(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithSortAlerts(nil)(opts)
+
+	if opts.sortLess != nil {
+		t.Error("nil less function should be ignored")
+	}
+
+	WithSortAlerts(func(a, b *types.Alert) bool { return a.Header < b.Header })(opts)
+
+	if opts.sortLess == nil {
+		t.Error("expected sortLess to be set")
+	}
+}
+
+func TestWithAlertValidator(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithAlertValidator(nil)(opts)
+
+	if opts.alertValidator != nil {
+		t.Error("nil validator should be ignored")
+	}
+
+	WithAlertValidator(func(_ *types.Alert) error { return nil })(opts)
+
+	if opts.alertValidator == nil {
+		t.Error("expected alertValidator to be set")
+	}
+}
+
+func TestWithRetryMode(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+
+	if opts.retryMode != RetryAll {
+		t.Errorf("expected the default retryMode=RetryAll, got %v", opts.retryMode)
+	}
+
+	WithRetryMode(RetryConnectionOnly)(opts)
+
+	if opts.retryMode != RetryConnectionOnly {
+		t.Errorf("expected retryMode=RetryConnectionOnly, got %v", opts.retryMode)
+	}
+
+	WithRetryMode(RetryMode(-1))(opts)
+
+	if opts.retryMode != RetryConnectionOnly {
+		t.Errorf("unknown mode should be ignored, got %v", opts.retryMode)
+	}
+}
+
+func TestWithAlertKey(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithAlertKey(nil)(opts)
+
+	alert := &types.Alert{Header: "a", CorrelationID: "id"}
+
+	if opts.alertKey == nil || opts.alertKey(alert) != defaultAlertKey(alert) {
+		t.Error("nil function should be ignored")
+	}
+
+	WithAlertKey(func(alert *types.Alert) string { return alert.CorrelationID })(opts)
+
+	if got := opts.alertKey(alert); got != "id" {
+		t.Errorf("expected the configured key function, got key %q", got)
+	}
+}
+
+func TestWithVerifyResponseChecksum(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithVerifyResponseChecksum(true)(opts)
+
+	if !opts.verifyResponseChecksum {
+		t.Error("expected verifyResponseChecksum=true")
+	}
+
+	WithVerifyResponseChecksum(false)(opts)
+
+	if opts.verifyResponseChecksum {
+		t.Error("expected verifyResponseChecksum=false")
+	}
+}
+
+func TestWithConnectJitter(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithConnectJitter(time.Second)(opts)
+
+	if opts.connectJitter != time.Second {
+		t.Errorf("expected connectJitter=1s, got %v", opts.connectJitter)
+	}
+
+	WithConnectJitter(-time.Second)(opts)
+
+	if opts.connectJitter != time.Second {
+		t.Errorf("negative value should be ignored, got %v", opts.connectJitter)
+	}
+
+	WithConnectJitter(0)(opts)
+
+	if opts.connectJitter != 0 {
+		t.Errorf("expected connectJitter=0, got %v", opts.connectJitter)
+	}
+}
+
+func TestWithBatchCallback(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithBatchCallback(nil)(opts)
+
+	if opts.batchCallback != nil {
+		t.Error("nil callback should be ignored")
+	}
+
+	WithBatchCallback(func(_ context.Context, _, _ int, _ error) {})(opts)
+
+	if opts.batchCallback == nil {
+		t.Error("expected batchCallback to be set")
+	}
+}
+
+func TestWithDeliveryFailureSink(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithDeliveryFailureSink(nil)(opts)
+
+	if opts.deliveryFailureSink != nil {
+		t.Error("nil sink should be ignored")
+	}
+
+	WithDeliveryFailureSink(func(_ context.Context, _ []*types.Alert, _ error) {})(opts)
+
+	if opts.deliveryFailureSink == nil {
+		t.Error("expected deliveryFailureSink to be set")
+	}
+}
+
+func TestWithMinSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected types.AlertSeverity
+	}{
+		{"warning", "warning", types.AlertWarning},
+		{"normalized", " Panic ", types.AlertPanic},
+		{"critical means error", "critical", types.AlertError},
+		{"empty ignored", "", ""},
+		{"resolved ignored", "resolved", ""},
+		{"unknown ignored", "debug", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithMinSeverity(tt.input)(opts)
+
+			if opts.minSeverity != tt.expected {
+				t.Errorf("expected minSeverity=%q, got %q", tt.expected, opts.minSeverity)
+			}
+		})
+	}
+}
+
+func TestWithConnectPingTolerance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		failures         int
+		interval         time.Duration
+		expectedFailures int
+		expectedInterval time.Duration
+	}{
+		{"valid", 3, time.Second, 3, time.Second},
+		{"zero failures", 0, time.Second, 0, time.Second},
+		{"negative failures ignored", -1, time.Second, 0, 0},
+		{"too many failures ignored", 101, time.Second, 0, 0},
+		{"zero interval ignored", 3, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithConnectPingTolerance(tt.failures, tt.interval)(opts)
+
+			if opts.pingTolerance != tt.expectedFailures || opts.pingInterval != tt.expectedInterval {
+				t.Errorf("expected tolerance=%d interval=%v, got tolerance=%d interval=%v", tt.expectedFailures, tt.expectedInterval, opts.pingTolerance, opts.pingInterval)
+			}
+		})
+	}
+}
+
+func TestWithConnMaxLifetime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected time.Duration
+	}{
+		{"valid", time.Minute, time.Minute},
+		{"zero disables", 0, 0},
+		{"negative ignored", -time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithConnMaxLifetime(tt.input)(opts)
+
+			if opts.connMaxLifetime != tt.expected {
+				t.Errorf("expected connMaxLifetime=%v, got %v", tt.expected, opts.connMaxLifetime)
+			}
+		})
+	}
+}
+
+func TestWithConnectProbes(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithConnectProbes("health", " options  alerts ", "", "  ")(opts)
+	WithConnectProbes("HEAD status")(opts)
+
+	expected := []connectProbe{
+		{method: "GET", path: "health"},
+		{method: "OPTIONS", path: "alerts"},
+		{method: "HEAD", path: "status"},
+	}
+
+	if !slices.Equal(opts.connectProbes, expected) {
+		t.Errorf("expected probes %v, got %v", expected, opts.connectProbes)
+	}
+}
+
+func TestWithConnectFallbackEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     string
+		method   string
+		expected connectProbe
+	}{
+		{"path and method", " alerts ", "options", connectProbe{method: "OPTIONS", path: "alerts"}},
+		{"empty method defaults to HEAD", "alerts", "", connectProbe{method: "HEAD", path: "alerts"}},
+		{"empty path ignored", "  ", "GET", connectProbe{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithConnectFallbackEndpoint(tt.path, tt.method)(opts)
+
+			if opts.connectFallback != tt.expected {
+				t.Errorf("expected fallback %v, got %v", tt.expected, opts.connectFallback)
+			}
+		})
+	}
+}
+
+func TestWithPayloadValidator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid function", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newClientOptions()
+		WithPayloadValidator(func([]byte) error { return nil })(opts)
+
+		if opts.payloadValidator == nil {
+			t.Error("expected payloadValidator to be set")
+		}
+	})
+
+	t.Run("nil ignored", func(t *testing.T) {
+		t.Parallel()
+
+		opts := newClientOptions()
+		WithPayloadValidator(nil)(opts)
+
+		if opts.payloadValidator != nil {
+			t.Error("nil function should be ignored")
+		}
+	})
+}
+
+func TestWithRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithRateLimitHeaders(nil)(opts)
+
+	if opts.rateLimitHeadersFn != nil {
+		t.Error("expected nil callback to be ignored")
+	}
+
+	WithRateLimitHeaders(func(context.Context, int, time.Time) {})(opts)
+
+	if opts.rateLimitHeadersFn == nil {
+		t.Error("expected callback to be set")
+	}
+}
+
+func TestWithAlertsEndpointResolver(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithAlertsEndpointResolver(nil)(opts)
+
+	if opts.endpointResolver != nil {
+		t.Error("expected nil resolver to be ignored")
+	}
+
+	WithAlertsEndpointResolver(func([]*types.Alert) string { return "eu/alerts" })(opts)
+
+	if opts.endpointResolver == nil {
+		t.Error("expected resolver to be set")
+	}
+}
+
+func TestWithAcceptCompression(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithAcceptCompression(false)(opts)
+
+	if opts.acceptCompression {
+		t.Error("expected acceptCompression=false")
+	}
+
+	WithAcceptCompression(true)(opts)
+
+	if !opts.acceptCompression {
+		t.Error("expected acceptCompression=true")
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+
+	if _, ok := opts.clock.(systemClock); !ok {
+		t.Fatalf("expected system clock by default, got %T", opts.clock)
+	}
+
+	WithClock(nil)(opts)
+
+	if _, ok := opts.clock.(systemClock); !ok {
+		t.Errorf("expected nil clock to be ignored, got %T", opts.clock)
+	}
+
+	clock := newFakeClock(time.Now())
+	WithClock(clock)(opts)
+
+	if opts.clock != clock {
+		t.Error("expected fake clock to be set")
+	}
+}
+
+func TestWithAutoTimestamp(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithAutoTimestamp(true)(opts)
+
+	if !opts.autoTimestamp {
+		t.Error("expected autoTimestamp=true")
+	}
+
+	WithAutoTimestamp(false)(opts)
+
+	if opts.autoTimestamp {
+		t.Error("expected autoTimestamp=false")
+	}
+}
+
+func TestWithClientCertificateReloader(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithClientCertificateReloader(nil)(opts)
+
+	if opts.clientCertReloader != nil {
+		t.Error("nil reloader should be ignored")
+	}
+
+	WithClientCertificateReloader(func() (tls.Certificate, error) { return tls.Certificate{}, nil })(opts)
+
+	if opts.clientCertReloader == nil {
+		t.Error("expected clientCertReloader to be set")
+	}
+}
+
+func TestWithTLSCipherSuites(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithTLSCipherSuites(tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256)(opts)
+	WithTLSCipherSuites()(opts)
+
+	if len(opts.tlsCipherSuites) != 2 {
+		t.Fatalf("expected an empty list to be ignored, got %v", opts.tlsCipherSuites)
+	}
+
+	WithTLSCipherSuites(tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384)(opts)
+
+	if len(opts.tlsCipherSuites) != 1 || opts.tlsCipherSuites[0] != tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384 {
+		t.Errorf("expected a later call to replace the list, got %v", opts.tlsCipherSuites)
+	}
+}
+
+func TestWithCertificatePin(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithCertificatePin(" AB:CD ", "", "ef01")(opts)
+	WithCertificatePin("2345")(opts)
+
+	expected := []string{"abcd", "ef01", "2345"}
+	if !slices.Equal(opts.certificatePins, expected) {
+		t.Errorf("expected pins %v, got %v", expected, opts.certificatePins)
+	}
+}
+
+func TestWithAuditWriter(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithAuditWriter(nil)(opts)
+
+	if opts.auditWriter != nil {
+		t.Error("expected nil writer to be ignored")
+	}
+
+	var buf bytes.Buffer
+	WithAuditWriter(&buf)(opts)
+
+	if opts.auditWriter != &buf {
+		t.Error("expected writer to be set")
+	}
+}
+
+func TestWithRetryOnBodyContains(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithRetryOnBodyContains("queue full", "")(opts)
+	WithRetryOnBodyContains("try again")(opts)
+
+	expected := []string{"queue full", "try again"}
+	if !slices.Equal(opts.retryBodySubstrings, expected) {
+		t.Errorf("expected substrings %v, got %v", expected, opts.retryBodySubstrings)
+	}
+}
+
+func TestWithMaxResponseHeaderBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"valid", 16 << 10, 16 << 10},
+		{"minimum", 4 << 10, 4 << 10},
+		{"maximum", 1 << 20, 1 << 20},
+		{"too small ignored", 1024, 64 << 10},
+		{"too large ignored", 2 << 20, 64 << 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithMaxResponseHeaderBytes(tt.input)(opts)
+
+			if opts.maxResponseHeaders != tt.expected {
+				t.Errorf("expected maxResponseHeaders=%d, got %d", tt.expected, opts.maxResponseHeaders)
+			}
+		})
+	}
+}
+
+func TestWithProfile(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithProfile(" resilient ")(opts)
+	WithProfile("")(opts)
+
+	if opts.profile != "resilient" {
+		t.Errorf("expected profile=resilient, got %q", opts.profile)
+	}
+}
+
+func TestWithExpectedPingBody(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithExpectedPingBody(`"status":"ok"`)(opts)
+
+	if opts.expectedPingBody != `"status":"ok"` {
+		t.Errorf("expected expectedPingBody to be set, got %q", opts.expectedPingBody)
+	}
+
+	WithExpectedPingBody("")(opts)
+
+	if opts.expectedPingBody != "" {
+		t.Errorf("expected expectedPingBody to be cleared, got %q", opts.expectedPingBody)
+	}
+}
+
+func TestWithSharedTransport(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithSharedTransport(nil)(opts)
+
+	if opts.sharedTransport != nil {
+		t.Error("expected nil transport to be ignored")
+	}
+
+	transport := &http.Transport{}
+	WithSharedTransport(transport)(opts)
+
+	if opts.sharedTransport != transport {
+		t.Error("expected shared transport to be set")
+	}
+
+	if err := opts.Validate(); err != nil {
+		t.Errorf("expected shared transport with default options to be valid, got %v", err)
+	}
+}
+
+func TestWithLargeBatchWarning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"valid", 500, 500},
+		{"zero disables", 0, 0},
+		{"negative ignored", -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithLargeBatchWarning(tt.input)(opts)
+
+			if opts.largeBatchThreshold != tt.expected {
+				t.Errorf("expected largeBatchThreshold=%d, got %d", tt.expected, opts.largeBatchThreshold)
+			}
+		})
+	}
+}
+
+func TestWithDefaultRequestContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected time.Duration
+	}{
+		{"valid", 10 * time.Second, 10 * time.Second},
+		{"zero disables", 0, 0},
+		{"negative ignored", -time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithDefaultRequestContext(tt.input)(opts)
+
+			if opts.defaultRequestTimeout != tt.expected {
+				t.Errorf("expected defaultRequestTimeout=%v, got %v", tt.expected, opts.defaultRequestTimeout)
+			}
+		})
+	}
+}
+
+func TestWithIndentedJSON(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithIndentedJSON(true)(opts)
+
+	if !opts.indentJSON {
+		t.Error("expected indentJSON=true")
+	}
+
+	WithIndentedJSON(false)(opts)
+
+	if opts.indentJSON {
+		t.Error("expected indentJSON=false")
+	}
+}
+
+func TestWithRandSource(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithRandSource(nil)(opts)
+
+	if _, ok := opts.randSource.(globalRandSource); !ok {
+		t.Errorf("nil source should be ignored, got %T", opts.randSource)
+	}
+
+	WithRandSource(rand.NewPCG(1, 2))(opts)
+
+	if _, ok := opts.randSource.(*lockedRandSource); !ok {
+		t.Errorf("expected a locked source, got %T", opts.randSource)
+	}
+}
+
+func TestWithStreamingEncode(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithStreamingEncode(true)(opts)
+
+	if !opts.streamingEncode {
+		t.Error("expected streamingEncode=true")
+	}
+
+	WithStreamingEncode(false)(opts)
+
+	if opts.streamingEncode {
+		t.Error("expected streamingEncode=false")
+	}
+}
+
+func TestWithMaxRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    time.Duration
+		expected time.Duration
+	}{
+		{"valid", time.Minute, time.Minute},
+		{"too short ignored", 10 * time.Millisecond, 0},
+		{"too long ignored", 24 * time.Hour, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithMaxRetryAfter(tt.input)(opts)
+
+			if opts.maxRetryAfter != tt.expected {
+				t.Errorf("expected maxRetryAfter=%v, got %v", tt.expected, opts.maxRetryAfter)
+			}
+		})
+	}
+}
+
+func TestOptionsRetryAfterCap(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+
+	if got := opts.retryAfterCap(); got != opts.retryMaxWaitTime {
+		t.Errorf("expected the cap to default to retryMaxWaitTime, got %v", got)
+	}
+
+	WithMaxRetryAfter(time.Minute)(opts)
+
+	if got := opts.retryAfterCap(); got != time.Minute {
+		t.Errorf("expected cap=1m, got %v", got)
+	}
+}
+
+func TestWithFieldEncryption(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+
+	opts := newClientOptions()
+	WithFieldEncryption(key)(opts)
+
+	if opts.encryptedFields != nil || opts.encryptionKey != nil {
+		t.Error("expected a call without fields to be ignored")
+	}
+
+	WithFieldEncryption(key, " text ", "", "footer", "text")(opts)
+
+	if !slices.Equal(opts.encryptedFields, []string{"text", "footer"}) {
+		t.Errorf("expected fields [text footer], got %v", opts.encryptedFields)
+	}
+
+	key[0] = 1
+
+	if opts.encryptionKey[0] != 0 {
+		t.Error("expected key to be copied")
+	}
+}
+
+func TestWithDialNetwork(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ipv4", "tcp4", "tcp4"},
+		{"ipv6", "tcp6", "tcp6"},
+		{"either", "tcp", "tcp"},
+		{"udp ignored", "udp", "tcp"},
+		{"empty ignored", "", "tcp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithDialNetwork(tt.input)(opts)
+
+			if opts.dialNetwork != tt.expected {
+				t.Errorf("expected dialNetwork=%q, got %q", tt.expected, opts.dialNetwork)
+			}
+		})
+	}
+}
+
+func TestWithStatusLogging(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+
+	if opts.statusLogging {
+		t.Error("expected status logging to be disabled by default")
+	}
+
+	WithStatusLogging(true)(opts)
+
+	if !opts.statusLogging {
+		t.Error("expected status logging to be enabled")
+	}
+}
+
+func TestWithStatusLogLevels(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithStatusLogLevels(map[int]string{
+		2: LogLevelOff,
+		4: LogLevelError,
+		5: "fatal",
+		6: LogLevelWarn,
+	})(opts)
+
+	expected := map[int]string{1: LogLevelDebug, 2: LogLevelOff, 3: LogLevelDebug, 4: LogLevelError, 5: LogLevelError}
+
+	if !maps.Equal(opts.statusLogLevels, expected) {
+		t.Errorf("expected levels %v, got %v", expected, opts.statusLogLevels)
+	}
+}
+
+func TestWithNoRetryAboveBodySize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"valid", 1 << 20, 1 << 20},
+		{"zero disables", 0, 0},
+		{"negative ignored", -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithNoRetryAboveBodySize(tt.input)(opts)
+
+			if opts.noRetryAboveBodySize != tt.expected {
+				t.Errorf("expected noRetryAboveBodySize=%d, got %d", tt.expected, opts.noRetryAboveBodySize)
+			}
+		})
+	}
+}
+
+func TestWithObserver(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithObserver(nil)(opts)
+
+	if opts.observer != nil {
+		t.Error("expected nil observer to be ignored")
+	}
+
+	observer := &recordingObserver{}
+	WithObserver(observer)(opts)
+
+	if opts.observer != observer {
+		t.Error("expected observer to be set")
+	}
+}
+
+func TestWithValidateConnOnUse(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+
+	if opts.validateConnOnUse {
+		t.Error("expected connection validation to be disabled by default")
+	}
+
+	WithValidateConnOnUse(true)(opts)
+
+	if !opts.validateConnOnUse {
+		t.Error("expected connection validation to be enabled")
+	}
+}
+
+func TestWithResponseTimeoutRetry(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+
+	if opts.responseTimeoutRetry {
+		t.Error("expected response timeouts not to be retried by default")
+	}
+
+	WithResponseTimeoutRetry(true)(opts)
+
+	if !opts.responseTimeoutRetry {
+		t.Error("expected response timeouts to be retried")
+	}
+}
+
+func TestWithRedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithRedirectPolicy(nil)(opts)
+
+	if opts.redirectPolicy != nil {
+		t.Error("expected nil redirect policy to be ignored")
+	}
+
+	WithRedirectPolicy(SameHostRedirectPolicy)(opts)
+
+	if opts.redirectPolicy == nil {
+		t.Error("expected redirect policy to be set")
+	}
+}
+
+func TestWithPreferredAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"valid", "v2", "v2"},
+		{"multi-digit", "v10", "v10"},
+		{"missing prefix ignored", "2", ""},
+		{"v0 ignored", "v0", ""},
+		{"empty ignored", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithPreferredAPIVersion(tt.input)(opts)
+
+			if opts.preferredAPIVersion != tt.expected {
+				t.Errorf("expected preferredAPIVersion=%q, got %q", tt.expected, opts.preferredAPIVersion)
+			}
+		})
+	}
+}
+
+func TestWithStrictVersioning(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithStrictVersioning(true)(opts)
+
+	if !opts.strictVersioning {
+		t.Error("expected strict versioning to be enabled")
+	}
+}
+
+func TestWithAdaptiveThrottle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		base         time.Duration
+		maxDelay     time.Duration
+		expectedBase time.Duration
+		expectedMax  time.Duration
+	}{
+		{"valid", 100 * time.Millisecond, 5 * time.Second, 100 * time.Millisecond, 5 * time.Second},
+		{"equal delays", time.Second, time.Second, time.Second, time.Second},
+		{"zero base ignored", 0, time.Second, 0, 0},
+		{"max below base ignored", time.Second, time.Millisecond, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithAdaptiveThrottle(tt.base, tt.maxDelay)(opts)
+
+			if opts.throttleBaseDelay != tt.expectedBase || opts.throttleMaxDelay != tt.expectedMax {
+				t.Errorf("expected delays %v/%v, got %v/%v", tt.expectedBase, tt.expectedMax, opts.throttleBaseDelay, opts.throttleMaxDelay)
+			}
+		})
+	}
+}
+
+func TestWithExpectedResponseVersion(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithExpectedResponseVersion("  ")(opts)
+
+	if opts.expectedResponseVersion != "" {
+		t.Errorf("expected empty version to be ignored, got %q", opts.expectedResponseVersion)
+	}
+
+	WithExpectedResponseVersion(" v2 ")(opts)
+
+	if opts.expectedResponseVersion != "v2" {
+		t.Errorf("expected expectedResponseVersion=v2, got %q", opts.expectedResponseVersion)
+	}
+}
+
+func TestWithMaxInFlightBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int64
+		expected int64
+	}{
+		{"valid", 64 << 20, 64 << 20},
+		{"zero disables", 0, 0},
+		{"negative ignored", -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithMaxInFlightBytes(tt.input)(opts)
+
+			if opts.maxInFlightBytes != tt.expected {
+				t.Errorf("expected maxInFlightBytes=%d, got %d", tt.expected, opts.maxInFlightBytes)
+			}
+		})
+	}
+}
+
+func TestWithDrainContext(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithDrainContext(nil)(opts) //nolint:staticcheck // verifies nil is ignored
+
+	if opts.drainCtx != nil {
+		t.Error("expected nil context to be ignored")
+	}
+
+	ctx := context.Background()
+	WithDrainContext(ctx)(opts)
+
+	if opts.drainCtx != ctx {
+		t.Error("expected drain context to be set")
+	}
+}
+
+func TestWithMaxLabelsPerAlert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    int
+		expected int
+	}{
+		{"valid", 20, 20},
+		{"zero disables", 0, 0},
+		{"negative ignored", -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions()
+			WithMaxLabelsPerAlert(tt.input)(opts)
+
+			if opts.maxLabelsPerAlert != tt.expected {
+				t.Errorf("expected maxLabelsPerAlert=%d, got %d", tt.expected, opts.maxLabelsPerAlert)
+			}
+		})
+	}
+}
+
+func TestWithTruncateExcessLabels(t *testing.T) {
+	t.Parallel()
+
+	opts := newClientOptions()
+	WithTruncateExcessLabels(true)(opts)
+
+	if !opts.truncateExcessLabels {
+		t.Error("expected truncation to be enabled")
+	}
+}
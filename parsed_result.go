@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
+)
+
+// SendWithParsedResult posts one or more alerts like [Client.Send] and runs
+// parse on the body of the successful response, returning its typed result.
+// This keeps the client agnostic to the response schema, which differs
+// between server versions. An empty body is passed to parse as nil.
+//
+// If the send fails, parse is not called and the zero value of T is returned
+// with the send error. When [WithSeverityEndpoint] splits the alerts into
+// several requests, the body of the last successful request is parsed.
+//
+// Go does not allow type parameters on methods, so this is a package-level
+// function taking the client as its first argument.
+func SendWithParsedResult[T any](ctx context.Context, c *Client, parse func([]byte) (T, error), alerts ...*types.Alert) (T, error) {
+	var zero T
+
+	if parse == nil {
+		return zero, errors.New("result parser cannot be nil")
+	}
+
+	var body []byte
+
+	call := &callOptions{
+		onSuccess: func(response *resty.Response) { body = response.Body() },
+	}
+
+	if _, err := c.send(ctx, call, alerts); err != nil {
+		return zero, err
+	}
+
+	if len(body) == 0 {
+		body = nil
+	}
+
+	result, err := parse(body)
+	if err != nil {
+		return zero, fmt.Errorf("failed to parse response body: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+type acceptedResult struct {
+	Accepted int `json:"accepted"`
+}
+
+func parseAccepted(body []byte) (acceptedResult, error) {
+	var result acceptedResult
+
+	if body == nil {
+		return result, nil
+	}
+
+	err := json.Unmarshal(body, &result)
+
+	return result, err
+}
+
+func TestSendWithParsedResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses the success body", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newRecordingClient(t, replyWith(http.StatusOK, `{"accepted":2}`))
+
+		result, err := SendWithParsedResult(context.Background(), c, parseAccepted, &types.Alert{}, &types.Alert{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Accepted != 2 {
+			t.Errorf("expected accepted=2, got %d", result.Accepted)
+		}
+	})
+
+	t.Run("empty body is passed as nil", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newRecordingClient(t, replyWith(http.StatusNoContent, ""))
+
+		called := false
+
+		_, err := SendWithParsedResult(context.Background(), c, func(body []byte) (int, error) {
+			called = true
+
+			if body != nil {
+				t.Errorf("expected nil body, got %q", body)
+			}
+
+			return 0, nil
+		}, &types.Alert{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !called {
+			t.Error("expected parser to be called")
+		}
+	})
+
+	t.Run("parser error is wrapped", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newRecordingClient(t, replyWith(http.StatusOK, `not json`))
+
+		_, err := SendWithParsedResult(context.Background(), c, parseAccepted, &types.Alert{})
+		if err == nil || !strings.Contains(err.Error(), "failed to parse response body") {
+			t.Fatalf("expected parse error, got %v", err)
+		}
+	})
+
+	t.Run("send failure skips the parser", func(t *testing.T) {
+		t.Parallel()
+
+		c, _ := newRecordingClient(t, replyWith(http.StatusBadRequest, `{"error":"bad"}`))
+
+		_, err := SendWithParsedResult(context.Background(), c, func([]byte) (int, error) {
+			return 0, errors.New("parser should not be called")
+		}, &types.Alert{})
+		if err == nil || !strings.Contains(err.Error(), "status code 400") {
+			t.Fatalf("expected status error, got %v", err)
+		}
+	})
+
+	t.Run("nil parser", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := SendWithParsedResult[int](context.Background(), New("http://example.com"), nil, &types.Alert{})
+		if err == nil || err.Error() != "result parser cannot be nil" {
+			t.Fatalf("expected nil parser error, got %v", err)
+		}
+	})
+}
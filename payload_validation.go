@@ -0,0 +1,17 @@
+package client
+
+import "fmt"
+
+// validatePayload runs the validator configured with [WithPayloadValidator]
+// on a marshaled request body. It is a no-op when no validator is configured.
+func (c *Client) validatePayload(body []byte) error {
+	if c.options.payloadValidator == nil {
+		return nil
+	}
+
+	if err := c.options.payloadValidator(body); err != nil {
+		return fmt.Errorf("%w: %w", ErrPayloadValidation, err)
+	}
+
+	return nil
+}
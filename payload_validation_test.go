@@ -0,0 +1,59 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+var errMissingHeader = errors.New("alert without header")
+
+func TestSend_PayloadValidator(t *testing.T) {
+	t.Parallel()
+
+	var posts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posts.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	validator := func(body []byte) error {
+		if bytes.Contains(body, []byte(`"header":""`)) {
+			return errMissingHeader
+		}
+
+		return nil
+	}
+
+	c := New(server.URL, WithPayloadValidator(validator))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "valid"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := c.Send(context.Background(), &types.Alert{Text: "no header"})
+	if !errors.Is(err, ErrPayloadValidation) {
+		t.Fatalf("expected ErrPayloadValidation, got %v", err)
+	}
+
+	if !errors.Is(err, errMissingHeader) {
+		t.Errorf("expected the validator's error to be wrapped, got %v", err)
+	}
+
+	if n := posts.Load(); n != 1 {
+		t.Errorf("expected only the valid payload to be posted, got %d posts", n)
+	}
+}
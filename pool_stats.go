@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http/httptrace"
+	"sync"
+)
+
+// PoolStats is a snapshot of the client's connection pool, as returned by
+// [Client.PoolStats].
+type PoolStats struct {
+	// Open is the number of open connections, idle or in use.
+	Open int
+
+	// InUse is the number of connections currently serving a request.
+	InUse int
+
+	// Idle is the number of open connections waiting in the pool for reuse.
+	Idle int
+}
+
+// PoolStats returns a snapshot of the connection pool. It is only populated
+// when the client was created with [WithPoolStats] and has connected;
+// otherwise the zero value is returned. The counts are approximate while
+// requests are in flight.
+func (c *Client) PoolStats() PoolStats {
+	if c == nil || !c.options.poolStats {
+		return PoolStats{}
+	}
+
+	open := int(c.openConns.Load())
+	inUse := min(int(c.inUseConns.Load()), open)
+
+	return PoolStats{
+		Open:  open,
+		InUse: inUse,
+		Idle:  open - inUse,
+	}
+}
+
+// dialFunc is the signature of [http.Transport.DialContext].
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// countingDialContext wraps dial so that every connection it opens is
+// counted in the client's open-connection gauge until it is closed.
+func (c *Client) countingDialContext(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		c.openConns.Add(1)
+
+		return &countedConn{Conn: conn, onClose: func() { c.openConns.Add(-1) }}, nil
+	}
+}
+
+// countedConn is a net.Conn that runs onClose exactly once when closed.
+type countedConn struct {
+	net.Conn
+
+	once    sync.Once
+	onClose func()
+}
+
+func (c *countedConn) Close() error {
+	c.once.Do(c.onClose)
+	return c.Conn.Close()
+}
+
+// connUsage tracks the connections held by a single request, so that the
+// client's in-use gauge can be corrected even when the transport does not
+// report a connection being returned to the pool.
+type connUsage struct {
+	client *Client
+	mu     sync.Mutex
+	held   int
+}
+
+// withTrace returns a copy of ctx carrying the usage hooks.
+func (u *connUsage) withTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn:     u.gotConn,
+		PutIdleConn: u.putIdleConn,
+	})
+}
+
+func (u *connUsage) gotConn(_ httptrace.GotConnInfo) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.held++
+	u.client.inUseConns.Add(1)
+}
+
+func (u *connUsage) putIdleConn(_ error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.held > 0 {
+		u.held--
+		u.client.inUseConns.Add(-1)
+	}
+}
+
+// release marks any connections still held by the request as no longer in
+// use. It is called once the request has completed.
+func (u *connUsage) release() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.client.inUseConns.Add(int64(-u.held))
+	u.held = 0
+}
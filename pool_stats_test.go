@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestClient_PoolStats_Disabled(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if stats := c.PoolStats(); stats != (PoolStats{}) {
+		t.Errorf("expected zero stats when disabled, got %+v", stats)
+	}
+}
+
+func TestClient_PoolStats(t *testing.T) {
+	t.Parallel()
+
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			entered <- struct{}{}
+			<-unblock
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithPoolStats(true))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	waitForStats(t, c, PoolStats{Open: 1, Idle: 1})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Send(context.Background(), &types.Alert{Header: "test"})
+	}()
+
+	<-entered
+
+	if stats := c.PoolStats(); stats != (PoolStats{Open: 1, InUse: 1}) {
+		t.Errorf("expected one in-use connection during send, got %+v", stats)
+	}
+
+	close(unblock)
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	waitForStats(t, c, PoolStats{Open: 1, Idle: 1})
+
+	c.Close()
+
+	waitForStats(t, c, PoolStats{})
+}
+
+// waitForStats polls until the pool stats match expected, since connections
+// are returned to the pool and closed asynchronously by the transport.
+func waitForStats(t *testing.T, c *Client, expected PoolStats) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if c.PoolStats() == expected {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Errorf("expected pool stats %+v, got %+v", expected, c.PoolStats())
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Profile names accepted by [WithProfile].
+const (
+	// ProfileLowLatency favours fast failure: a 2 second timeout, no retries
+	// and idle connections kept open for the maximum 5 minutes so that
+	// requests rarely pay for a new connection. It does not warm up
+	// connections ahead of the first send: only the connection opened by
+	// the connect ping is kept.
+	ProfileLowLatency = "lowlatency"
+
+	// ProfileResilient favours delivery: 10 retries with jittered
+	// exponential backoff between 1 and 30 seconds, and up to 5 tolerated
+	// ping failures at connect. It has no circuit breaker: every send keeps
+	// retrying against a failing server, as the client has no breaker to
+	// enable.
+	ProfileResilient = "resilient"
+
+	// ProfileDevelopment is for local development only: it disables TLS
+	// certificate verification, logging a warning at connect, and logs
+	// every request and response at debug level with credentials redacted.
+	ProfileDevelopment = "development"
+)
+
+// profileField is a set of the fields that profiles set. The options setting
+// one of them record it in [Options] profileOverrides, so that a profile
+// applied in the same [Options.Apply] call leaves it alone.
+type profileField uint8
+
+const (
+	profileTimeout profileField = 1 << iota
+	profileRetryCount
+	profileRetryWaitTime
+	profileRetryMaxWaitTime
+	profileIdleConnTimeout
+	profilePingTolerance
+	profileTLSConfig
+)
+
+// profileOption returns opt as part of a profile: it is skipped when an
+// option of the same [Options.Apply] call has set field.
+func profileOption(field profileField, opt Option) Option {
+	return func(o *Options) {
+		if o.profileOverrides&field == 0 {
+			opt(o)
+		}
+	}
+}
+
+// profileOptions returns the options making up the named profile.
+func profileOptions(name string) ([]Option, bool) {
+	switch name {
+	case ProfileLowLatency:
+		return []Option{
+			profileOption(profileTimeout, WithTimeout(2*time.Second)),
+			profileOption(profileRetryCount, WithRetryCount(0)),
+			profileOption(profileIdleConnTimeout, WithIdleConnTimeout(maxIdleConnTimeout)),
+		}, true
+	case ProfileResilient:
+		return []Option{
+			profileOption(profileRetryCount, WithRetryCount(10)),
+			profileOption(profileRetryWaitTime, WithRetryWaitTime(time.Second)),
+			profileOption(profileRetryMaxWaitTime, WithRetryMaxWaitTime(30*time.Second)),
+			profileOption(profilePingTolerance, WithConnectPingTolerance(5, 2*time.Second)),
+		}, true
+	case ProfileDevelopment:
+		return []Option{
+			profileOption(profileTLSConfig, WithTLSConfig(&tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12})), //nolint:gosec // development profile only
+			func(o *Options) { o.debug = true },
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// redactRequestLog removes credentials from debug request logs.
+func redactRequestLog(log *resty.RequestLog) error {
+	for _, header := range []string{"Authorization", "Proxy-Authorization", "Cookie"} {
+		if log.Header.Get(header) != "" {
+			log.Header.Set(header, redactedValue)
+		}
+	}
+
+	return nil
+}
+
+// enableDebugLogging logs every request and response through the request
+// logger at debug level.
+func enableDebugLogging(client *resty.Client) {
+	client.SetDebug(true).OnRequestLog(redactRequestLog)
+}
+
+// isInsecureTLS reports whether transport skips certificate verification.
+func isInsecureTLS(transport *http.Transport) bool {
+	return transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
+}
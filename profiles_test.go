@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// recordingLogger is a [RequestLogger] that keeps every formatted message.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) record(level, format string, v ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, level+": "+fmt.Sprintf(format, v...))
+}
+
+func (l *recordingLogger) Errorf(format string, v ...any) { l.record("error", format, v...) }
+func (l *recordingLogger) Warnf(format string, v ...any)  { l.record("warn", format, v...) }
+func (l *recordingLogger) Debugf(format string, v ...any) { l.record("debug", format, v...) }
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return strings.Join(l.messages, "\n")
+}
+
+func TestProfiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		profile string
+		check   func(*Options) bool
+	}{
+		{ProfileLowLatency, func(o *Options) bool {
+			return o.timeout == 2*time.Second && o.retryCount == 0 && o.idleConnTimeout == 5*time.Minute
+		}},
+		{ProfileResilient, func(o *Options) bool {
+			return o.retryCount == 10 && o.retryWaitTime == time.Second && o.retryMaxWaitTime == 30*time.Second && o.pingTolerance == 5
+		}},
+		{ProfileDevelopment, func(o *Options) bool {
+			return o.debug && o.tlsConfig != nil && o.tlsConfig.InsecureSkipVerify
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			t.Parallel()
+
+			opts := newClientOptions().Apply(WithProfile(tt.profile))
+
+			if !tt.check(opts) {
+				t.Errorf("profile %s not applied: %+v", tt.profile, opts)
+			}
+
+			if err := opts.Validate(); err != nil {
+				t.Errorf("expected profile %s to be valid, got %v", tt.profile, err)
+			}
+		})
+	}
+}
+
+func TestProfiles_ExplicitOptionsOverride(t *testing.T) {
+	t.Parallel()
+
+	// The explicit option wins even though the profile is given last
+	opts := newClientOptions().Apply(WithRetryCount(1), WithProfile(ProfileResilient))
+
+	if opts.retryCount != 1 {
+		t.Errorf("expected explicit retryCount=1 to win, got %d", opts.retryCount)
+	}
+
+	if opts.retryWaitTime != time.Second {
+		t.Errorf("expected profile retryWaitTime=1s, got %v", opts.retryWaitTime)
+	}
+}
+
+func TestProfiles_OptionsRunOnce(t *testing.T) {
+	t.Parallel()
+
+	var runs int
+
+	counting := func(o *Options) {
+		runs++
+		WithTimeout(10 * time.Second)(o)
+	}
+
+	opts := newClientOptions().Apply(counting, WithProfile(ProfileLowLatency))
+
+	if runs != 1 {
+		t.Errorf("expected the option to run once, got %d runs", runs)
+	}
+
+	if opts.timeout != 10*time.Second || opts.retryCount != 0 {
+		t.Errorf("expected the explicit timeout and the profile retry count, got timeout=%v retryCount=%d", opts.timeout, opts.retryCount)
+	}
+}
+
+func TestProfiles_ExplicitDefaultValueWins(t *testing.T) {
+	t.Parallel()
+
+	// An explicit option wins even when it sets the default value
+	opts := newClientOptions().Apply(WithProfile(ProfileResilient), WithRetryCount(3))
+
+	if opts.retryCount != 3 {
+		t.Errorf("expected explicit retryCount=3 to win, got %d", opts.retryCount)
+	}
+}
+
+func TestProfiles_ResolvedPerApplyCall(t *testing.T) {
+	t.Parallel()
+
+	// A profile only yields to the options of its own Apply call
+	opts := newClientOptions().Apply(WithRetryCount(1)).Apply(WithProfile(ProfileResilient))
+
+	if opts.retryCount != 10 {
+		t.Errorf("expected the later profile to set retryCount=10, got %d", opts.retryCount)
+	}
+}
+
+func TestConnect_UnknownProfile(t *testing.T) {
+	t.Parallel()
+
+	c := New("http://example.com", WithProfile("turbo"))
+
+	err := c.Connect(context.Background())
+	if err == nil || err.Error() != `invalid options: unknown profile "turbo"` {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+}
+
+func TestConnect_DevelopmentProfile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+
+	c := New(server.URL, WithProfile(ProfileDevelopment), WithRequestLogger(logger), WithAuthToken("secret-token"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs := logger.String()
+
+	if !strings.Contains(logs, "warn: TLS certificate verification is disabled") {
+		t.Errorf("expected an insecure TLS warning, got:\n%s", logs)
+	}
+
+	if !strings.Contains(logs, "debug: ") || !strings.Contains(logs, "/alerts") {
+		t.Errorf("expected debug request logs, got:\n%s", logs)
+	}
+
+	if strings.Contains(logs, "secret-token") {
+		t.Errorf("expected credentials to be redacted, got:\n%s", logs)
+	}
+}
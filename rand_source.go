@@ -0,0 +1,30 @@
+package client
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// globalRandSource is the default random source, backed by the top-level
+// functions of math/rand/v2, which are securely seeded and safe for
+// concurrent use.
+type globalRandSource struct{}
+
+func (globalRandSource) Uint64() uint64 {
+	return rand.Uint64() //nolint:gosec // jitter does not need a secure source
+}
+
+// lockedRandSource serialises access to a source set with [WithRandSource],
+// as the sources of math/rand/v2 are not safe for concurrent use while
+// retries of concurrent sends draw from it.
+type lockedRandSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedRandSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.src.Uint64()
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRandSource_FixedSeedIsReproducible(t *testing.T) {
+	t.Parallel()
+
+	sequence := func() []time.Duration {
+		opts := newClientOptions()
+		WithRandSource(rand.NewPCG(1, 2))(opts)
+
+		waits := make([]time.Duration, 0, 6)
+		for attempt := range 6 {
+			waits = append(waits, jitterBackoff(opts.randSource, 100*time.Millisecond, 5*time.Second, attempt))
+		}
+
+		return waits
+	}
+
+	first, second := sequence(), sequence()
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected identical sequences, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestLockedRandSource_ConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	src := &lockedRandSource{src: rand.NewPCG(1, 2)}
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Go(func() {
+			for range 100 {
+				jitterBackoff(src, 100*time.Millisecond, time.Second, 3)
+			}
+		})
+	}
+
+	wg.Wait()
+}
+
+func TestGlobalRandSource(t *testing.T) {
+	t.Parallel()
+
+	src := globalRandSource{}
+
+	if src.Uint64() == src.Uint64() && src.Uint64() == src.Uint64() {
+		t.Error("expected the global source to produce varying values")
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+
+	// minEpochReset separates X-RateLimit-Reset values given as a Unix
+	// timestamp from those given as seconds until the reset. No server
+	// grants a window of more than 30 years.
+	minEpochReset = 1_000_000_000
+)
+
+// parseRateLimitHeaders extracts the remaining request budget and the time
+// it resets from the X-RateLimit-Remaining and X-RateLimit-Reset headers.
+// The reset may be a Unix timestamp or a number of seconds from now; it is
+// the zero time when missing or invalid. ok is false when the remaining
+// budget is missing or invalid.
+func parseRateLimitHeaders(h http.Header, now time.Time) (remaining int, resetAt time.Time, ok bool) {
+	remaining, err := strconv.Atoi(strings.TrimSpace(h.Get(rateLimitRemainingHeader)))
+	if err != nil || remaining < 0 {
+		return 0, time.Time{}, false
+	}
+
+	if reset, err := strconv.ParseInt(strings.TrimSpace(h.Get(rateLimitResetHeader)), 10, 64); err == nil && reset >= 0 {
+		if reset >= minEpochReset {
+			resetAt = time.Unix(reset, 0)
+		} else {
+			resetAt = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	return remaining, resetAt, true
+}
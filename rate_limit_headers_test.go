@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		remaining string
+		reset     string
+		wantOK    bool
+		wantRem   int
+		wantReset time.Time
+	}{
+		{"missing", "", "", false, 0, time.Time{}},
+		{"invalid remaining", "many", "10", false, 0, time.Time{}},
+		{"negative remaining", "-1", "10", false, 0, time.Time{}},
+		{"remaining only", "42", "", true, 42, time.Time{}},
+		{"delta seconds", "5", "30", true, 5, now.Add(30 * time.Second)},
+		{"unix timestamp", "0", "1767323045", true, 0, time.Unix(1767323045, 0)},
+		{"invalid reset", "7", "soon", true, 7, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := http.Header{}
+			if tt.remaining != "" {
+				h.Set("X-RateLimit-Remaining", tt.remaining)
+			}
+			if tt.reset != "" {
+				h.Set("X-RateLimit-Reset", tt.reset)
+			}
+
+			remaining, resetAt, ok := parseRateLimitHeaders(h, now)
+
+			if ok != tt.wantOK || remaining != tt.wantRem || !resetAt.Equal(tt.wantReset) {
+				t.Errorf("expected (%d, %v, %v), got (%d, %v, %v)", tt.wantRem, tt.wantReset, tt.wantOK, remaining, resetAt, ok)
+			}
+		})
+	}
+}
+
+func TestSend_RateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			w.Header().Set("X-RateLimit-Remaining", "3")
+			w.Header().Set("X-RateLimit-Reset", "1767323045")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var calls int
+	var gotRemaining int
+	var gotReset time.Time
+
+	c := New(server.URL, WithRateLimitHeaders(func(_ context.Context, remaining int, resetAt time.Time) {
+		calls++
+		gotRemaining = remaining
+		gotReset = resetAt
+	}))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	// The ping response carries no rate limit headers
+	if calls != 0 {
+		t.Fatalf("expected no callback without headers, got %d calls", calls)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls != 1 || gotRemaining != 3 || !gotReset.Equal(time.Unix(1767323045, 0)) {
+		t.Errorf("expected one call with remaining=3, got %d calls with remaining=%d reset=%v", calls, gotRemaining, gotReset)
+	}
+}
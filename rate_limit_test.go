@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestTokenBucket_Burst(t *testing.T) {
+	t.Parallel()
+
+	bucket := newTokenBucket(1, 2)
+
+	if d := bucket.reserve(); d != 0 {
+		t.Errorf("expected first token immediately, got wait %v", d)
+	}
+
+	if d := bucket.reserve(); d != 0 {
+		t.Errorf("expected second token immediately, got wait %v", d)
+	}
+
+	if d := bucket.reserve(); d <= 0 || d > time.Second {
+		t.Errorf("expected third token to wait up to 1s, got %v", d)
+	}
+}
+
+func TestTokenBucket_WaitCancelled(t *testing.T) {
+	t.Parallel()
+
+	bucket := newTokenBucket(0.1, 1)
+	_ = bucket.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := bucket.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestHostRateLimiter_PerHost(t *testing.T) {
+	t.Parallel()
+
+	limiter := newHostRateLimiter(1, 1)
+
+	if limiter.bucket("a.example.com") != limiter.bucket("a.example.com") {
+		t.Error("expected the bucket for a host to be reused")
+	}
+
+	if limiter.bucket("a.example.com") == limiter.bucket("b.example.com") {
+		t.Error("expected distinct hosts to have distinct buckets")
+	}
+}
+
+func TestSend_PerHostRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The ping takes the only burst token, so each send waits ~50ms
+	c := New(server.URL, WithPerHostRateLimit(20, 1))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	start := time.Now()
+
+	for range 2 {
+		if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected sends to be rate limited, took %v", elapsed)
+	}
+}
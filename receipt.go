@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// processingDelayHeader is the response header with which the server asks
+// for the next receipt poll to be delayed.
+const processingDelayHeader = "X-Processing-Delay"
+
+// receipt is the body returned by the read-receipt endpoint.
+type receipt struct {
+	Acknowledged bool `json:"acknowledged"`
+}
+
+// WaitForReceipt polls the read receipt of the alert with the given ID,
+// GET {alerts endpoint}/{id}/receipt, every pollInterval until a human has
+// acknowledged it or ctx is done. It returns true once the receipt reports
+// "acknowledged": true. When ctx is done first it returns false with the
+// context's error. Any failed poll ends the wait with that error.
+// [Client.Connect] must be called first.
+//
+// A poll answered with an X-Processing-Delay header, in seconds or as an
+// HTTP-date like Retry-After, waits for that delay before the next poll
+// instead of pollInterval, so that a busy server can slow the polling down.
+func (c *Client) WaitForReceipt(ctx context.Context, id string, pollInterval time.Duration) (bool, error) {
+	if c == nil {
+		return false, errors.New("alert client is nil")
+	}
+
+	if c.client == nil {
+		return false, errors.New("client not connected - call Connect() first")
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return false, errors.New("alert ID must not be empty")
+	}
+
+	if pollInterval <= 0 {
+		return false, errors.New("poll interval must be positive")
+	}
+
+	path := c.versionedEndpoint(c.options.alertsEndpoint) + "/" + url.PathEscape(id) + "/receipt"
+
+	for {
+		acknowledged, delay, err := c.getReceipt(ctx, path)
+		if err != nil || acknowledged {
+			return acknowledged, err
+		}
+
+		if delay <= 0 {
+			delay = pollInterval
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return false, ctx.Err()
+		}
+	}
+}
+
+// getReceipt fetches a read receipt and reports whether it is acknowledged,
+// along with the processing delay requested by the server, if any.
+func (c *Client) getReceipt(ctx context.Context, path string) (bool, time.Duration, error) {
+	response, err := c.fetch(ctx, resty.MethodGet, path)
+	if err != nil {
+		return false, 0, err
+	}
+
+	var r receipt
+	if err := json.Unmarshal(response.Body(), &r); err != nil {
+		return false, 0, fmt.Errorf("failed to decode receipt from %s: %w", path, err)
+	}
+
+	return r.Acknowledged, parseDelayHeader(response.Header(), processingDelayHeader, c.options.clock), nil
+}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_WaitForReceipt(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/ping":
+			w.WriteHeader(http.StatusOK)
+		case "/alerts/alert%2F1/receipt":
+			if polls.Add(1) < 3 {
+				_, _ = w.Write([]byte(`{"acknowledged":false}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	acknowledged, err := c.WaitForReceipt(context.Background(), "alert/1", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !acknowledged {
+		t.Error("expected the receipt to be acknowledged")
+	}
+
+	if n := polls.Load(); n != 3 {
+		t.Errorf("expected 3 polls, got %d", n)
+	}
+}
+
+func TestClient_WaitForReceipt_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged":false}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	acknowledged, err := c.WaitForReceipt(ctx, "1", 10*time.Millisecond)
+
+	if acknowledged || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected (false, deadline exceeded), got (%v, %v)", acknowledged, err)
+	}
+}
+
+func TestClient_WaitForReceipt_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			w.WriteHeader(http.StatusOK)
+		case "/alerts/garbled/receipt":
+			_, _ = w.Write([]byte(`not json`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"unknown alert"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		id            string
+		interval      time.Duration
+		expectedError string
+	}{
+		{"empty ID", " ", time.Second, "alert ID must not be empty"},
+		{"invalid interval", "1", 0, "poll interval must be positive"},
+		{"error status", "missing", time.Second, "failed with status code 404: unknown alert"},
+		{"invalid body", "garbled", time.Second, "failed to decode receipt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			acknowledged, err := c.WaitForReceipt(context.Background(), tt.id, tt.interval)
+
+			if acknowledged || err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("expected error containing %q, got (%v, %v)", tt.expectedError, acknowledged, err)
+			}
+		})
+	}
+}
+
+func TestClient_WaitForReceipt_NotConnected(t *testing.T) {
+	t.Parallel()
+
+	var nilClient *Client
+	if _, err := nilClient.WaitForReceipt(context.Background(), "1", time.Second); err == nil || err.Error() != "alert client is nil" {
+		t.Errorf("expected nil client error, got %v", err)
+	}
+
+	if _, err := New("http://example.com").WaitForReceipt(context.Background(), "1", time.Second); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected not connected error, got %v", err)
+	}
+}
+
+func TestClient_WaitForReceipt_ProcessingDelay(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		polls.Add(1)
+		w.Header().Set(processingDelayHeader, "60")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"acknowledged":false}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	_, delay, err := c.getReceipt(context.Background(), "alerts/1/receipt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if delay != time.Minute {
+		t.Errorf("expected a 1m processing delay, got %v", delay)
+	}
+
+	polls.Store(0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.WaitForReceipt(ctx, "1", 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	if n := polls.Load(); n != 1 {
+		t.Errorf("expected the processing delay to replace the poll interval, got %d polls", n)
+	}
+}
+
+func TestClient_WaitForReceipt_NegotiatedVersion(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			_, _ = w.Write([]byte(`{"supported_versions":["v1","v2"]}`))
+		case "/v2/alerts/a1/receipt":
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithPreferredAPIVersion("v2"))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	acknowledged, err := c.WaitForReceipt(context.Background(), "a1", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected the receipt to be polled under /v2, got: %v", err)
+	}
+
+	if !acknowledged {
+		t.Error("expected the receipt to be acknowledged")
+	}
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// SameHostRedirectPolicy is a redirect policy for [WithRedirectPolicy] that
+// only follows redirects to the host, including the port, of the original
+// request. This prevents an open redirect on the server from sending the
+// client, and its credentials, to a location controlled by someone else.
+func SameHostRedirectPolicy(req *http.Request, via []*http.Request) error {
+	if len(via) == 0 {
+		return nil
+	}
+
+	if origin := via[0].URL.Host; !strings.EqualFold(req.URL.Host, origin) {
+		return fmt.Errorf("redirect to %s rejected: not the same host as %s", req.URL.Host, origin)
+	}
+
+	return nil
+}
+
+// redirectPolicies returns the redirect policies of the client: the limit
+// set with [WithMaxRedirects], followed by the policy set with
+// [WithRedirectPolicy], if any.
+func (c *Client) redirectPolicies() []any {
+	policies := []any{resty.FlexibleRedirectPolicy(c.options.maxRedirects)}
+
+	if c.options.redirectPolicy != nil {
+		policies = append(policies, resty.RedirectPolicyFunc(c.options.redirectPolicy))
+	}
+
+	return policies
+}
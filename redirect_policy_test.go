@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSameHostRedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	origin, _ := http.NewRequest(http.MethodPost, "https://alerts.example.com:8443/alerts", nil)
+
+	tests := []struct {
+		name    string
+		target  string
+		wantErr bool
+	}{
+		{"same host", "https://alerts.example.com:8443/v2/alerts", false},
+		{"same host different case", "https://ALERTS.example.com:8443/v2/alerts", false},
+		{"different host", "https://attacker.example.net/alerts", true},
+		{"different port", "https://alerts.example.com:9443/alerts", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequest(http.MethodPost, tt.target, nil)
+
+			err := SameHostRedirectPolicy(req, []*http.Request{origin})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestClient_RedirectPolicy(t *testing.T) {
+	t.Parallel()
+
+	var elsewhereHits atomic.Int32
+
+	elsewhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		elsewhereHits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(elsewhere.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		switch r.URL.Path {
+		case "/alerts":
+			http.Redirect(w, r, "/v2/alerts", http.StatusTemporaryRedirect)
+		case "/offsite":
+			http.Redirect(w, r, elsewhere.URL+"/alerts", http.StatusTemporaryRedirect)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("same-host redirect is followed", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithRedirectPolicy(SameHostRedirectPolicy))
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+
+		if err := c.Send(context.Background(), &types.Alert{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("cross-host redirect is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithRedirectPolicy(SameHostRedirectPolicy), WithAlertsEndpoint("offsite"), WithRetryCount(0))
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+
+		err := c.Send(context.Background(), &types.Alert{})
+		if err == nil || !strings.Contains(err.Error(), "not the same host") {
+			t.Fatalf("expected redirect to be rejected, got %v", err)
+		}
+
+		if elsewhereHits.Load() != 0 {
+			t.Error("expected the other host not to be contacted")
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestTimings holds the duration of each phase of an HTTP request, as
+// measured with [net/http/httptrace]. When a request is retried, the timings
+// describe the final attempt. Phases that did not occur, such as DNS lookup
+// and connect on a reused connection, are zero.
+type RequestTimings struct {
+	DNSLookup       time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	TimeToFirstByte time.Duration
+	ConnReused      bool
+}
+
+// requestTracerKey is the context key of the [requestTracer] of a request.
+type requestTracerKey struct{}
+
+// requestTracer collects [RequestTimings] for a single request. The trace
+// hooks fire from several transport goroutines, so all state is guarded by mu.
+type requestTracer struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timings      RequestTimings
+}
+
+// withTrace returns a copy of ctx carrying the tracer and its hooks.
+func (t *requestTracer) withTrace(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, requestTracerKey{}, t)
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn:              t.getConn,
+		DNSStart:             t.dnsStartHook,
+		DNSDone:              t.dnsDone,
+		ConnectStart:         t.connectStartHook,
+		ConnectDone:          t.connectDone,
+		TLSHandshakeStart:    t.tlsHandshakeStart,
+		TLSHandshakeDone:     t.tlsHandshakeDone,
+		GotConn:              t.gotConn,
+		GotFirstResponseByte: t.gotFirstResponseByte,
+	})
+}
+
+// result returns the timings of the most recent attempt.
+func (t *requestTracer) result() RequestTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.timings
+}
+
+// timeToFirstByte returns the time to first byte of the last attempt of the
+// request behind r, or zero if it got no response.
+func timeToFirstByte(r *resty.Response) time.Duration {
+	if r == nil || r.Request == nil {
+		return 0
+	}
+
+	t, ok := r.Request.Context().Value(requestTracerKey{}).(*requestTracer)
+	if !ok {
+		return 0
+	}
+
+	return t.result().TimeToFirstByte
+}
+
+// LastTTFB returns the time to first byte of the most recent request made
+// by the client, measured from the start of its final attempt to the first
+// byte of the response. Unlike the total duration, it does not depend on
+// the size of the bodies, so it isolates the responsiveness of the server.
+// It is zero before the first request and when the most recent request got
+// no response.
+func (c *Client) LastTTFB() time.Duration {
+	if c == nil {
+		return 0
+	}
+
+	return time.Duration(c.lastTTFB.Load())
+}
+
+// getConn marks the start of an attempt and discards timings of any
+// previous attempt.
+func (t *requestTracer) getConn(_ string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.start = time.Now()
+	t.dnsStart = time.Time{}
+	t.connectStart = time.Time{}
+	t.tlsStart = time.Time{}
+	t.timings = RequestTimings{}
+}
+
+func (t *requestTracer) dnsStartHook(_ httptrace.DNSStartInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.dnsStart = time.Now()
+}
+
+func (t *requestTracer) dnsDone(_ httptrace.DNSDoneInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.dnsStart.IsZero() {
+		t.timings.DNSLookup = time.Since(t.dnsStart)
+	}
+}
+
+// connectStartHook records the first dial only; with happy eyeballs several
+// dials may race for the same request.
+func (t *requestTracer) connectStartHook(_, _ string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.connectStart.IsZero() {
+		t.connectStart = time.Now()
+	}
+}
+
+func (t *requestTracer) connectDone(_, _ string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil && !t.connectStart.IsZero() && t.timings.Connect == 0 {
+		t.timings.Connect = time.Since(t.connectStart)
+	}
+}
+
+func (t *requestTracer) tlsHandshakeStart() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tlsStart = time.Now()
+}
+
+func (t *requestTracer) tlsHandshakeDone(_ tls.ConnectionState, _ error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.tlsStart.IsZero() {
+		t.timings.TLSHandshake = time.Since(t.tlsStart)
+	}
+}
+
+func (t *requestTracer) gotConn(info httptrace.GotConnInfo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timings.ConnReused = info.Reused
+}
+
+func (t *requestTracer) gotFirstResponseByte() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.start.IsZero() {
+		t.timings.TimeToFirstByte = time.Since(t.start)
+	}
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// bodyChecksumHeader is the response header carrying the hex-encoded
+// SHA-256 of the response body, see [WithVerifyResponseChecksum].
+const bodyChecksumHeader = "X-Body-SHA256"
+
+// verifyResponseChecksum compares the body of response to its
+// X-Body-SHA256 header when [WithVerifyResponseChecksum] is enabled. A
+// missing header is not checked, and a mismatch, including a header that is
+// not a valid checksum, returns an error wrapping
+// [ErrResponseChecksumMismatch].
+func (c *Client) verifyResponseChecksum(response *resty.Response) error {
+	if !c.options.verifyResponseChecksum {
+		return nil
+	}
+
+	expected := strings.TrimSpace(response.Header().Get(bodyChecksumHeader))
+	if expected == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256(response.Body())
+
+	if want, err := hex.DecodeString(expected); err != nil || string(want) != string(sum[:]) {
+		return fmt.Errorf("%w: %s %s returned a body with SHA-256 %s, the %s header is %q",
+			ErrResponseChecksumMismatch, response.Request.Method, sanitizeURL(response.Request.URL), hex.EncodeToString(sum[:]), bodyChecksumHeader, expected)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func newChecksumServer(t *testing.T, body, checksum string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" && checksum != "" {
+			w.Header().Set(bodyChecksumHeader, checksum)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerifyResponseChecksum(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"status":"ok"}`
+
+	tests := []struct {
+		name      string
+		checksum  string
+		enabled   bool
+		wantError bool
+	}{
+		{"matching checksum", sha256Hex(body), true, false},
+		{"matching uppercase checksum", strings.ToUpper(sha256Hex(body)), true, false},
+		{"no header", "", true, false},
+		{"mismatch", sha256Hex("corrupted"), true, true},
+		{"invalid header", "not-hex", true, true},
+		{"mismatch when disabled", sha256Hex("corrupted"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newChecksumServer(t, body, tt.checksum)
+
+			c := New(server.URL, WithRetryCount(0), WithVerifyResponseChecksum(tt.enabled))
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			meta, err := c.SendWithResponse(context.Background(), &types.Alert{Text: "test"})
+
+			if tt.wantError {
+				if !errors.Is(err, ErrResponseChecksumMismatch) {
+					t.Fatalf("expected ErrResponseChecksumMismatch, got %v", err)
+				}
+
+				if meta == nil || meta.StatusCode != http.StatusOK {
+					t.Errorf("expected the response metadata, got %+v", meta)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyResponseChecksum_Ping(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(bodyChecksumHeader, sha256Hex("pong"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("corrupted"))
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithVerifyResponseChecksum(true))
+
+	if err := c.Connect(context.Background()); !errors.Is(err, ErrResponseChecksumMismatch) {
+		t.Errorf("expected ErrResponseChecksumMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http/httptrace"
+	"sync/atomic"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// writeTrackerKey is the context key of the [writeTracker] of a request.
+type writeTrackerKey struct{}
+
+// writeTracker records whether the current attempt of a request has written
+// the request to the connection, so that a timeout can be told apart as a
+// connect timeout (nothing sent) or a response timeout (request sent).
+type writeTracker struct {
+	wrote atomic.Bool
+}
+
+// withTrace returns a copy of ctx carrying the tracker and its hooks.
+func (w *writeTracker) withTrace(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, writeTrackerKey{}, w)
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GetConn:      w.getConn,
+		WroteRequest: w.wroteRequest,
+	})
+}
+
+// getConn is called at the start of every attempt, including the ones the
+// transport makes on its own.
+func (w *writeTracker) getConn(_ string) {
+	w.wrote.Store(false)
+}
+
+func (w *writeTracker) wroteRequest(info httptrace.WroteRequestInfo) {
+	if info.Err == nil {
+		w.wrote.Store(true)
+	}
+}
+
+// wroteRequest reports whether the last attempt of the request behind r was
+// written in full before it failed.
+func wroteRequest(r *resty.Response) bool {
+	if r == nil || r.Request == nil {
+		return false
+	}
+
+	w, ok := r.Request.Context().Value(writeTrackerKey{}).(*writeTracker)
+
+	return ok && w.wrote.Load()
+}
+
+// isAttemptTimeout reports whether err is a timeout of a single attempt, as
+// opposed to the caller's context running out.
+func isAttemptTimeout(r *resty.Response, err error) bool {
+	if err == nil || r == nil || r.Request == nil || r.Request.Context().Err() != nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// responseTimeoutRetry reports whether a POST whose attempt timed out is
+// retried because of [WithResponseTimeoutRetry], whether or not the request
+// was written.
+func (c *Client) responseTimeoutRetry(r *resty.Response, err error) bool {
+	return c.options.responseTimeoutRetry && isAttemptTimeout(r, err) && r.Request.Method == resty.MethodPost
+}
+
+// timeoutRetryVetoed reports whether a POST that timed out must not be
+// retried, whatever the retry policy says. A timeout waiting for the
+// response may already have delivered the alerts, so it is only retried
+// with [WithResponseTimeoutRetry]. A timeout before the request was written
+// is left to the retry policy like any other failure.
+func (c *Client) timeoutRetryVetoed(r *resty.Response, err error) bool {
+	if !isAttemptTimeout(r, err) || r.Request.Method != resty.MethodPost {
+		return false
+	}
+
+	if !wroteRequest(r) || c.options.responseTimeoutRetry {
+		return false
+	}
+
+	c.options.requestLogger.Debugf("not retrying %s %s: timed out after the request was sent, so the alerts may have been delivered",
+		r.Request.Method, sanitizeURL(r.Request.URL))
+
+	return true
+}
@@ -0,0 +1,280 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
+)
+
+// newHangingServer returns a server that reads the alerts and then waits
+// for the client to give up before answering.
+func newHangingServer(t *testing.T, attempts *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+			attempts.Add(1)
+
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestResponseTimeoutRetry_NotRetriedByDefault(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := newHangingServer(t, &attempts)
+	logger := &recordingLogger{}
+
+	c := New(server.URL, WithRetryCount(1), WithRetryWaitTime(minRetryWaitTime), WithRequestLogger(logger))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	c.client.SetTimeout(50 * time.Millisecond)
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected a response timeout not to be retried, got %d attempts", got)
+	}
+
+	if !strings.Contains(logger.String(), "timed out after the request was sent") {
+		t.Errorf("expected a debug message about the skipped retry, got:\n%s", logger.String())
+	}
+}
+
+func TestResponseTimeoutRetry_RetriedWhenEnabled(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := newHangingServer(t, &attempts)
+
+	c := New(server.URL, WithRetryCount(1), WithRetryWaitTime(minRetryWaitTime), WithResponseTimeoutRetry(true))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	c.client.SetTimeout(50 * time.Millisecond)
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected a response timeout to be retried once, got %d attempts", got)
+	}
+}
+
+func TestResponseTimeoutRetry_ConnectTimeoutNotRetriedByDefault(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(1), WithRetryWaitTime(minRetryWaitTime))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	c.client.SetTimeout(50 * time.Millisecond)
+
+	var dials atomic.Int32
+
+	c.transport.CloseIdleConnections()
+	c.transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		dials.Add(1)
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := dials.Load(); got != 1 {
+		t.Errorf("expected the default retry policy not to retry a timeout, got %d dials", got)
+	}
+}
+
+func TestResponseTimeoutRetry_ConnectTimeoutRetriedWhenEnabled(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+			attempts.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(1), WithRetryWaitTime(minRetryWaitTime), WithResponseTimeoutRetry(true))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	c.client.SetTimeout(50 * time.Millisecond)
+
+	// The first dial hangs until the attempt times out, so the request is
+	// never written.
+	var dials atomic.Int32
+
+	dial := (&net.Dialer{}).DialContext
+	c.transport.CloseIdleConnections()
+	c.transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if dials.Add(1) == 1 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		return dial(ctx, network, addr)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("expected the connect timeout to be retried, got: %v", err)
+	}
+
+	if got := dials.Load(); got != 2 {
+		t.Errorf("expected 2 dials, got %d", got)
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected the alerts to be delivered once, got %d", got)
+	}
+}
+
+func TestResponseTimeoutRetry_ConnectTimeoutFollowsRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	neverRetry := func(*resty.Response, error) bool { return false }
+
+	c := New(server.URL, WithRetryCount(1), WithRetryWaitTime(minRetryWaitTime), WithRetryPolicy(neverRetry))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	c.client.SetTimeout(50 * time.Millisecond)
+
+	// Every dial hangs until the attempt times out, so the request is never
+	// written.
+	var dials atomic.Int32
+
+	c.transport.CloseIdleConnections()
+	c.transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		dials.Add(1)
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := dials.Load(); got != 1 {
+		t.Errorf("expected the never-retry policy to allow a single dial, got %d", got)
+	}
+}
+
+func TestResponseTimeoutRetry_ConnectTimeoutRetriedByPolicy(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	alwaysRetry := func(*resty.Response, error) bool { return true }
+
+	c := New(server.URL, WithRetryCount(1), WithRetryWaitTime(minRetryWaitTime), WithRetryPolicy(alwaysRetry))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	c.client.SetTimeout(50 * time.Millisecond)
+
+	var dials atomic.Int32
+
+	c.transport.CloseIdleConnections()
+	c.transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		dials.Add(1)
+		<-ctx.Done()
+
+		return nil, ctx.Err()
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := dials.Load(); got != 2 {
+		t.Errorf("expected the policy to retry a timeout before the request was written, got %d dials", got)
+	}
+}
+
+func TestResponseTimeoutRetry_CallerDeadlineNotRetried(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(1), WithRetryWaitTime(minRetryWaitTime))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	var dials atomic.Int32
+
+	c.transport.CloseIdleConnections()
+	c.transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		dials.Add(1)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := c.Send(ctx, &types.Alert{Text: "test"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := dials.Load(); got != 1 {
+		t.Errorf("expected the caller's deadline not to be retried, got %d dials", got)
+	}
+}
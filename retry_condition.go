@@ -0,0 +1,50 @@
+package client
+
+import (
+	"github.com/go-resty/resty/v2"
+)
+
+// retryCondition is the resty retry condition of the client. A request is
+// retried when the retry policy, [WithRetryOnBodyContains] or
+// [WithResponseTimeoutRetry] asks for it, unless the [RetryMode] excludes the failure, [Client.timeoutRetryVetoed]
+// vetoes it, its body is above the [WithNoRetryAboveBodySize] threshold or
+// its context deadline would expire during the wait before the retry.
+func (c *Client) retryCondition(r *resty.Response, err error) bool {
+	switch c.options.retryMode {
+	case RetryNone:
+		return false
+	case RetryConnectionOnly:
+		if err == nil {
+			return false
+		}
+	}
+
+	if c.timeoutRetryVetoed(r, err) {
+		return false
+	}
+
+	if !c.options.retryPolicy(r, err) && !c.bodyRetryCondition(r, err) && !c.responseTimeoutRetry(r, err) {
+		return false
+	}
+
+	if size, ok := requestBodySize(r); ok && c.options.noRetryAboveBodySize > 0 && size > c.options.noRetryAboveBodySize {
+		c.options.requestLogger.Debugf("not retrying %s %s: request body of %d bytes is above the retry threshold of %d bytes",
+			r.Request.Method, sanitizeURL(r.Request.URL), size, c.options.noRetryAboveBodySize)
+
+		return false
+	}
+
+	return c.retryFitsDeadline(r)
+}
+
+// requestBodySize returns the size of the body the client set on the request
+// behind r, if any.
+func requestBodySize(r *resty.Response) (int, bool) {
+	if r == nil || r.Request == nil {
+		return 0, false
+	}
+
+	body, ok := r.Request.Body.([]byte)
+
+	return len(body), ok
+}
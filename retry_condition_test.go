@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestRetryCondition_NoRetryAboveBodySize(t *testing.T) {
+	t.Parallel()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			_, _ = io.Copy(io.Discard, r.Body)
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := &recordingLogger{}
+
+	c := New(server.URL,
+		WithRetryCount(1),
+		WithRetryWaitTime(100*time.Millisecond),
+		WithNoRetryAboveBodySize(1024),
+		WithRequestLogger(logger),
+	)
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "small"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := attempts.Swap(0); got != 2 {
+		t.Errorf("expected a small body to be retried once, got %d attempts", got)
+	}
+
+	if err := c.Send(context.Background(), &types.Alert{Text: strings.Repeat("x", 2048)}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected a large body not to be retried, got %d attempts", got)
+	}
+
+	if !strings.Contains(logger.String(), "debug: not retrying POST") {
+		t.Errorf("expected a debug message about the skipped retry, got:\n%s", logger.String())
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import "strconv"
+
+// RetryMode selects which failures the retry policy acts on, see
+// [WithRetryMode].
+type RetryMode int
+
+const (
+	// RetryAll leaves every failure to the retry policy, so that both
+	// connection errors and retryable status codes are retried. It is the
+	// default.
+	RetryAll RetryMode = iota
+
+	// RetryConnectionOnly retries requests that failed without a response,
+	// such as a reset connection, and never retries on a status code or
+	// response body.
+	RetryConnectionOnly
+
+	// RetryNone never retries.
+	RetryNone
+)
+
+func (m RetryMode) String() string {
+	switch m {
+	case RetryAll:
+		return "RetryAll"
+	case RetryConnectionOnly:
+		return "RetryConnectionOnly"
+	case RetryNone:
+		return "RetryNone"
+	default:
+		return "RetryMode(" + strconv.Itoa(int(m)) + ")"
+	}
+}
+
+// valid reports whether m is one of the defined modes.
+func (m RetryMode) valid() bool {
+	return m >= RetryAll && m <= RetryNone
+}
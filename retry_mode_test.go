@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+// newFailingAlertsServer returns a server that fails every alerts request,
+// either with a 503 or, when dropConn is set, by closing the connection
+// without a response. It counts the alerts requests it receives.
+func newFailingAlertsServer(t *testing.T, dropConn bool) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alerts" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		attempts.Add(1)
+
+		if !dropConn {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Errorf("hijack failed: %v", err)
+			return
+		}
+
+		_ = conn.Close()
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &attempts
+}
+
+func TestRetryMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		mode             RetryMode
+		dropConn         bool
+		expectedAttempts int32
+	}{
+		{RetryAll, false, 2},
+		{RetryAll, true, 2},
+		{RetryConnectionOnly, false, 1},
+		{RetryConnectionOnly, true, 2},
+		{RetryNone, false, 1},
+		{RetryNone, true, 1},
+	}
+
+	for _, tt := range tests {
+		name := tt.mode.String() + "/status"
+		if tt.dropConn {
+			name = tt.mode.String() + "/connection"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server, attempts := newFailingAlertsServer(t, tt.dropConn)
+
+			c := New(server.URL,
+				WithRetryMode(tt.mode),
+				WithRetryCount(1),
+				WithRetryWaitTime(100*time.Millisecond),
+				WithRetryMaxWaitTime(100*time.Millisecond),
+			)
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			if err := c.Send(context.Background(), &types.Alert{Header: "test"}); err == nil {
+				t.Fatal("expected the send to fail")
+			}
+
+			if got := attempts.Load(); got != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, got)
+			}
+		})
+	}
+}
+
+func TestRetryMode_String(t *testing.T) {
+	t.Parallel()
+
+	tests := map[RetryMode]string{
+		RetryAll:            "RetryAll",
+		RetryConnectionOnly: "RetryConnectionOnly",
+		RetryNone:           "RetryNone",
+		RetryMode(7):        "RetryMode(7)",
+	}
+
+	for mode, expected := range tests {
+		if got := mode.String(); got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	}
+}
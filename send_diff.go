@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/slackmgr/types"
+)
+
+// diffState is the last-sent state of the alerts sent with
+// [Client.SendDiff].
+type diffState struct {
+	mu   sync.Mutex
+	sent map[string]diffEntry
+}
+
+// diffEntry is an alert last sent with [Client.SendDiff] and the digest of
+// its content at the time.
+type diffEntry struct {
+	alert  *types.Alert
+	digest [sha256.Size]byte
+}
+
+// SendDiff sends only the alerts that changed since the previous call, for
+// monitoring loops that re-evaluate their full alert set every interval.
+// Alerts are matched across calls by the key that keyFn returns for them,
+// or with a nil keyFn by the client's key function set with [WithAlertKey].
+// An alert is sent when its key is new or its content differs from the
+// alert last sent under that key. Alerts sent before whose keys are missing
+// from this call are resolved with [Client.Resolve], in the form they were
+// last sent. An empty call therefore resolves every alert sent before.
+//
+// The changed alerts are sent first, then the disappeared ones resolved.
+// The state is only updated for a request that succeeded, so a failed part
+// is sent again by the next call. Calls are serialized with each other.
+//
+// The state is kept in memory by the client, so it is lost on restart: the
+// first call of a new client sends the full alert set again, and cannot
+// resolve alerts that disappeared in the meantime.
+func (c *Client) SendDiff(ctx context.Context, keyFn func(*types.Alert) string, alerts ...*types.Alert) error {
+	if c == nil {
+		return errors.New("alert client is nil")
+	}
+
+	if keyFn == nil {
+		keyFn = c.options.alertKey
+	}
+
+	current := make(map[string]diffEntry, len(alerts))
+
+	var changed []*types.Alert
+
+	c.diff.mu.Lock()
+	defer c.diff.mu.Unlock()
+
+	for i, alert := range alerts {
+		if alert == nil {
+			return fmt.Errorf("alert at index %d is nil", i)
+		}
+
+		key := keyFn(alert)
+		if _, ok := current[key]; ok {
+			return fmt.Errorf("alert at index %d: duplicate alert key %q", i, key)
+		}
+
+		encoded, err := json.Marshal(alert)
+		if err != nil {
+			return fmt.Errorf("alert at index %d: failed to marshal alert: %w", i, err)
+		}
+
+		entry := diffEntry{alert: cloneAlert(alert), digest: sha256.Sum256(encoded)}
+		current[key] = entry
+
+		if last, ok := c.diff.sent[key]; !ok || last.digest != entry.digest {
+			changed = append(changed, alert)
+		}
+	}
+
+	var keys []string
+
+	for key := range c.diff.sent {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	disappeared := make([]*types.Alert, len(keys))
+	for i, key := range keys {
+		disappeared[i] = c.diff.sent[key].alert
+	}
+
+	if len(changed) > 0 {
+		if err := c.Send(ctx, changed...); err != nil && !errors.Is(err, ErrNoAlertsAfterFilter) {
+			return err
+		}
+	}
+
+	// The unchanged alerts are in the state already, and the changed ones
+	// have just been sent.
+	sent := current
+
+	if len(disappeared) > 0 {
+		if err := c.Resolve(ctx, disappeared...); err != nil {
+			for _, key := range keys {
+				sent[key] = c.diff.sent[key]
+			}
+
+			c.diff.sent = sent
+
+			return fmt.Errorf("failed to resolve disappeared alerts: %w", err)
+		}
+	}
+
+	c.diff.sent = sent
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+// diffRequests returns the requests recorded since the last call as the
+// joined texts of their alerts, prefixed with "resolve:" for resolutions.
+func diffRequests(recorder *requestRecorder) []string {
+	var requests []string
+
+	for _, r := range recorder.take() {
+		request := strings.Join(r.texts(), ",")
+		if r.query.Get("state") == "resolved" {
+			request = "resolve:" + request
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests
+}
+
+// failWhile returns a responder for [newRecordingClient] that fails requests
+// while fail is set.
+func failWhile(fail *atomic.Bool) func(attempt int) (int, string) {
+	return func(int) (int, string) {
+		if fail.Load() {
+			return http.StatusBadRequest, ""
+		}
+
+		return http.StatusOK, ""
+	}
+}
+
+func byHeader(alert *types.Alert) string {
+	return alert.Header
+}
+
+func TestSendDiff(t *testing.T) {
+	t.Parallel()
+
+	var fail atomic.Bool
+
+	c, recorder := newRecordingClient(t, failWhile(&fail))
+	ctx := context.Background()
+
+	steps := []struct {
+		name     string
+		alerts   []*types.Alert
+		expected []string
+	}{
+		{
+			name:     "first call sends everything",
+			alerts:   []*types.Alert{{Header: "a", Text: "a1"}, {Header: "b", Text: "b1"}, {Header: "c", Text: "c1"}},
+			expected: []string{"a1,b1,c1"},
+		},
+		{
+			name:     "unchanged alerts are skipped",
+			alerts:   []*types.Alert{{Header: "a", Text: "a1"}, {Header: "b", Text: "b1"}, {Header: "c", Text: "c1"}},
+			expected: nil,
+		},
+		{
+			name:     "changed alerts are sent and disappeared ones resolved",
+			alerts:   []*types.Alert{{Header: "a", Text: "a2"}, {Header: "c", Text: "c1"}, {Header: "d", Text: "d1"}},
+			expected: []string{"a2,d1", "resolve:b1"},
+		},
+		{
+			name:     "empty call resolves everything",
+			alerts:   nil,
+			expected: []string{"resolve:a2,c1,d1"},
+		},
+	}
+
+	for _, step := range steps {
+		if err := c.SendDiff(ctx, byHeader, step.alerts...); err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+
+		if got := diffRequests(recorder); strings.Join(got, "|") != strings.Join(step.expected, "|") {
+			t.Errorf("%s: expected requests %v, got %v", step.name, step.expected, got)
+		}
+	}
+}
+
+func TestSendDiff_FailedSendIsRetried(t *testing.T) {
+	t.Parallel()
+
+	var fail atomic.Bool
+
+	c, recorder := newRecordingClient(t, failWhile(&fail))
+	ctx := context.Background()
+
+	if err := c.SendDiff(ctx, byHeader, &types.Alert{Header: "a", Text: "a1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fail.Store(true)
+
+	if err := c.SendDiff(ctx, byHeader, &types.Alert{Header: "b", Text: "b1"}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	fail.Store(false)
+	recorder.take()
+
+	if err := c.SendDiff(ctx, byHeader, &types.Alert{Header: "b", Text: "b1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.Join(diffRequests(recorder), "|"); got != "b1|resolve:a1" {
+		t.Errorf("expected the failed send and resolution to be repeated, got %s", got)
+	}
+}
+
+func TestSendDiff_Errors(t *testing.T) {
+	t.Parallel()
+
+	var fail atomic.Bool
+
+	c, recorder := newRecordingClient(t, failWhile(&fail))
+	ctx := context.Background()
+
+	if err := c.SendDiff(ctx, byHeader, &types.Alert{Header: "a"}, nil); err == nil || err.Error() != "alert at index 1 is nil" {
+		t.Errorf("expected a nil alert error, got: %v", err)
+	}
+
+	err := c.SendDiff(ctx, byHeader, &types.Alert{Header: "a"}, &types.Alert{Header: "a"})
+	if err == nil || err.Error() != `alert at index 1: duplicate alert key "a"` {
+		t.Errorf("expected a duplicate key error, got: %v", err)
+	}
+
+	if got := diffRequests(recorder); len(got) != 0 {
+		t.Errorf("expected nothing to be sent, got %v", got)
+	}
+
+	var nilClient *Client
+	if err := nilClient.SendDiff(ctx, byHeader, &types.Alert{}); err == nil {
+		t.Error("expected error for nil client")
+	}
+}
+
+func TestSendDiff_ClientAlertKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{"default key resolves the changed alert", nil, "x|y,resolve:x"},
+		{"configured key matches the changed alert", []Option{WithAlertKey(byHeader)}, "x|y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var fail atomic.Bool
+
+			c, recorder := newRecordingClient(t, failWhile(&fail), tt.opts...)
+			ctx := context.Background()
+
+			var recorded []string
+
+			for _, text := range []string{"x", "x", "y"} {
+				if err := c.SendDiff(ctx, nil, &types.Alert{Header: "a", Text: text}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if got := diffRequests(recorder); len(got) > 0 {
+					recorded = append(recorded, strings.Join(got, ","))
+				}
+			}
+
+			if got := strings.Join(recorded, "|"); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/slackmgr/types"
+)
+
+// SendResult is the outcome of a send reported by the server, see
+// [Client.SendWithResult].
+type SendResult struct {
+	// IDs are the IDs of the alerts created by the server.
+	IDs []string `json:"ids"`
+
+	// Accepted is the number of alerts the server accepted.
+	Accepted int `json:"accepted"`
+
+	// Location is the URL of the created alerts from the Location header of
+	// the response, made absolute, or empty if the server sent none.
+	Location string `json:"-"`
+}
+
+// SendWithResult posts one or more alerts like [Client.Send] and returns the
+// alert IDs and accepted count from the body of the successful response,
+// and the URL of the created alerts from its Location header. A relative
+// Location is resolved against the request URL, as HTTP specifies. A body
+// that is empty or not in the expected form yields zero IDs and count
+// rather than an error, as older servers do not report them. When
+// [WithSeverityEndpoint] splits the alerts into several requests, the IDs
+// and counts of the successful requests are combined, and the first
+// Location is kept. If the send fails, the result is nil.
+//
+// Use [SendWithParsedResult] for responses in another form.
+func (c *Client) SendWithResult(ctx context.Context, alerts ...*types.Alert) (*SendResult, error) {
+	result := &SendResult{}
+
+	call := &callOptions{
+		onSuccess: func(response *resty.Response) {
+			if location := response.Header().Get("Location"); location != "" && result.Location == "" {
+				result.Location = resolveLocation(response.RawResponse.Request.URL, location)
+			}
+
+			var parsed SendResult
+			if err := json.Unmarshal(response.Body(), &parsed); err != nil {
+				return
+			}
+
+			result.IDs = append(result.IDs, parsed.IDs...)
+			result.Accepted += parsed.Accepted
+		},
+	}
+
+	if _, err := c.send(ctx, call, alerts); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// resolveLocation resolves the Location header value location against the
+// URL of the request it answered. Credentials of the request URL are not
+// carried over, and a value that is not a valid URL is returned as is.
+func resolveLocation(requestURL *url.URL, location string) string {
+	ref, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+
+	resolved := requestURL.ResolveReference(ref)
+	if ref.User == nil {
+		resolved.User = nil
+	}
+
+	return resolved.String()
+}
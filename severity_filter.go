@@ -0,0 +1,45 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/slackmgr/types"
+)
+
+// normalizeSeverity returns severity the way the API interprets it: trimmed
+// and lowercased, with an empty or "critical" severity meaning error and
+// "resolve", "recover" or "recovered" meaning resolved.
+func normalizeSeverity(severity types.AlertSeverity) types.AlertSeverity {
+	severity = types.AlertSeverity(strings.ToLower(strings.TrimSpace(string(severity))))
+
+	switch severity {
+	case "", "critical":
+		return types.AlertError
+	case "resolve", "recover", "recovered":
+		return types.AlertResolved
+	default:
+		return severity
+	}
+}
+
+// filterBySeverity drops the alerts below the severity set with
+// [WithMinSeverity]. Resolved alerts, which clear the issues raised by
+// earlier alerts, and alerts with an unknown severity are always kept.
+func (c *Client) filterBySeverity(alerts []*types.Alert) []*types.Alert {
+	threshold := types.SeverityPriority(c.options.minSeverity)
+	kept := make([]*types.Alert, 0, len(alerts))
+
+	for _, alert := range alerts {
+		severity := normalizeSeverity(alert.Severity)
+
+		if severity == types.AlertResolved || !types.SeverityIsValid(severity) || types.SeverityPriority(severity) >= threshold {
+			kept = append(kept, alert)
+		}
+	}
+
+	if dropped := len(alerts) - len(kept); dropped > 0 {
+		c.options.requestLogger.Debugf("dropped %d of %d alerts below the minimum severity %s", dropped, len(alerts), c.options.minSeverity)
+	}
+
+	return kept
+}
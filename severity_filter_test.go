@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestNormalizeSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    types.AlertSeverity
+		expected types.AlertSeverity
+	}{
+		{"warning", types.AlertWarning},
+		{" ERROR ", types.AlertError},
+		{"", types.AlertError},
+		{"critical", types.AlertError},
+		{"recovered", types.AlertResolved},
+		{"unknown", "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeSeverity(tt.input); got != tt.expected {
+			t.Errorf("normalizeSeverity(%q): expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestSend_MinSeverity(t *testing.T) {
+	t.Parallel()
+
+	var (
+		requests atomic.Int32
+		texts    atomic.Value
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			requests.Add(1)
+
+			body, _ := io.ReadAll(r.Body)
+
+			var payload alertsList
+			_ = json.Unmarshal(body, &payload)
+
+			var sent []string
+			for _, alert := range payload.Alerts {
+				sent = append(sent, alert.Text)
+			}
+
+			texts.Store(strings.Join(sent, ","))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := &recordingLogger{}
+
+	c := New(server.URL, WithMinSeverity("warning"), WithRequestLogger(logger))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	err := c.Send(context.Background(),
+		&types.Alert{Text: "info", Severity: types.AlertInfo},
+		&types.Alert{Text: "warning", Severity: types.AlertWarning},
+		&types.Alert{Text: "default"},
+		&types.Alert{Text: "resolved", Severity: types.AlertResolved},
+		&types.Alert{Text: "panic", Severity: types.AlertPanic},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := texts.Load(); got != "warning,default,resolved,panic" {
+		t.Errorf("expected the info alert to be dropped, got %v", got)
+	}
+
+	if !strings.Contains(logger.String(), "debug: dropped 1 of 5 alerts below the minimum severity warning") {
+		t.Errorf("expected a debug message about the dropped alert, got:\n%s", logger.String())
+	}
+
+	err = c.Send(context.Background(), &types.Alert{Text: "info", Severity: types.AlertInfo})
+	if !errors.Is(err, ErrNoAlertsAfterFilter) {
+		t.Errorf("expected ErrNoAlertsAfterFilter, got %v", err)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/slackmgr/types"
+)
+
+// Upper bounds of the encoded size of scalar values.
+const (
+	maxEncodedTimeSize  = len(`"2006-01-02T15:04:05.999999999-07:00"`)
+	maxEncodedIntSize   = len("-9223372036854775808")
+	maxEncodedUintSize  = len("18446744073709551615")
+	maxEncodedFloatSize = len("-1.2345678901234567e-308")
+	encodedNullSize     = len("null")
+	maxEncodedBoolSize  = len("false")
+	encodedEscapeSize   = len(`\u0000`)
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	marshalerType     = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// EstimateSendSize returns an estimate of the size in bytes of the request
+// body that sending alerts in one request produces, for planning batches
+// without marshaling them. The estimate is never below the actual size of
+// the compact JSON encoding, and is usually within a few percent of it. It
+// does not account for transformations made by the client before sending,
+// such as [WithGlobalLabels], [WithEnvelopeMetadata], [WithFieldEncryption] or
+// [WithIndentedJSON].
+func EstimateSendSize(alerts ...*types.Alert) int {
+	if len(alerts) == 0 {
+		return len(`{"alerts":null}`)
+	}
+
+	size := batchEnvelopeSize
+
+	for i, alert := range alerts {
+		if i > 0 {
+			size++ // separating comma
+		}
+
+		size += estimateJSONSize(reflect.ValueOf(alert))
+	}
+
+	return size
+}
+
+// estimateJSONSize returns an upper bound of the size of the JSON encoding of
+// v, walking it the way encoding/json does.
+func estimateJSONSize(v reflect.Value) int {
+	if !v.IsValid() {
+		return encodedNullSize
+	}
+
+	if v.Type() == timeType {
+		return maxEncodedTimeSize
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return encodedNullSize
+		}
+
+		return estimateJSONSize(v.Elem())
+	}
+
+	if v.Type().Implements(marshalerType) || v.Type().Implements(textMarshalerType) {
+		return estimateMarshaledSize(v)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return estimateStringSize(v.String())
+	case reflect.Bool:
+		return maxEncodedBoolSize
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return maxEncodedIntSize
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return maxEncodedUintSize
+	case reflect.Float32, reflect.Float64:
+		return maxEncodedFloatSize
+	case reflect.Slice:
+		if v.IsNil() {
+			return encodedNullSize
+		}
+
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodedLen(v.Len()) + 2
+		}
+
+		return estimateListSize(v)
+	case reflect.Array:
+		return estimateListSize(v)
+	case reflect.Map:
+		if v.IsNil() {
+			return encodedNullSize
+		}
+
+		return estimateMapSize(v)
+	case reflect.Struct:
+		return estimateStructSize(v)
+	default:
+		return estimateMarshaledSize(v)
+	}
+}
+
+// estimateStringSize returns the exact size of the JSON encoding of s,
+// including the HTML-safe escaping applied by encoding/json.
+func estimateStringSize(s string) int {
+	size := 2 // quotes
+
+	for i := 0; i < len(s); {
+		r, width := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case r == utf8.RuneError && width == 1:
+			size += utf8.RuneLen(utf8.RuneError) // replaced with U+FFFD
+		case r == '"' || r == '\\' || r == '\n' || r == '\r' || r == '\t':
+			size += 2
+		case r < 0x20 || r == '<' || r == '>' || r == '&':
+			size += encodedEscapeSize
+		case r == '\u2028' || r == '\u2029':
+			size += encodedEscapeSize
+		default:
+			size += width
+		}
+
+		i += width
+	}
+
+	return size
+}
+
+func estimateListSize(v reflect.Value) int {
+	size := 2 // brackets
+
+	for i := range v.Len() {
+		if i > 0 {
+			size++
+		}
+
+		size += estimateJSONSize(v.Index(i))
+	}
+
+	return size
+}
+
+func estimateMapSize(v reflect.Value) int {
+	size := 2 // braces
+
+	iter := v.MapRange()
+	for i := 0; iter.Next(); i++ {
+		if i > 0 {
+			size++
+		}
+
+		key := iter.Key()
+		if key.Kind() == reflect.String {
+			size += estimateStringSize(key.String())
+		} else {
+			// Non-string keys are encoded as quoted numbers or text.
+			size += estimateJSONSize(key) + 2
+		}
+
+		size += 1 + estimateJSONSize(iter.Value())
+	}
+
+	return size
+}
+
+func estimateStructSize(v reflect.Value) int {
+	size := 2 // braces
+	fields := 0
+
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if fields > 0 {
+			size++
+		}
+
+		fields++
+		size += estimateStringSize(name) + 1 + estimateJSONSize(v.Field(i))
+	}
+
+	return size
+}
+
+// estimateMarshaledSize measures values that encode themselves, or that the
+// estimator does not walk, by marshaling them.
+func estimateMarshaledSize(v reflect.Value) int {
+	if !v.CanInterface() {
+		return encodedNullSize
+	}
+
+	encoded, err := json.Marshal(v.Interface())
+	if err != nil {
+		return encodedNullSize
+	}
+
+	return len(encoded)
+}
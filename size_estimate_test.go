@@ -0,0 +1,73 @@
+package client
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestEstimateSendSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		alerts []*types.Alert
+	}{
+		{"empty alert", []*types.Alert{{}}},
+		{"no alerts", nil},
+		{"nil alert", []*types.Alert{nil}},
+		{"plain text", []*types.Alert{{Header: "disk full", Text: strings.Repeat("lorem ipsum ", 100), Severity: types.AlertError}}},
+		{"escaped characters", []*types.Alert{{Text: "<b>\"quoted\" & \\ \n\t\x01</b>"}}},
+		{"unicode", []*types.Alert{{Text: "日本語 🚨 émojis   "}}},
+		{"invalid utf-8", []*types.Alert{{Text: "bad \xff\xfe bytes"}}},
+		{"timestamps", []*types.Alert{{Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.FixedZone("x", -7*3600))}}},
+		{"nested", []*types.Alert{{
+			Fields:     []*types.Field{{Title: "a", Value: "b"}, nil},
+			Escalation: []*types.Escalation{{Severity: types.AlertPanic, DelaySeconds: 300, SlackMentions: []string{"@here"}}},
+			Webhooks: []*types.Webhook{{
+				ID:      "ack",
+				Payload: map[string]any{"n": 1.5, "list": []any{1, "two", nil}, "ip": net.ParseIP("10.0.0.1")},
+			}},
+			Metadata: map[string]any{"count": 42, "nested": map[string]any{"ok": true}, "bytes": []byte("raw")},
+		}}},
+		{"several alerts", []*types.Alert{{Text: "one"}, {Text: "two"}, {Text: "three"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := json.Marshal(alertsList{Alerts: tt.alerts})
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			actual := len(encoded)
+			estimate := EstimateSendSize(tt.alerts...)
+
+			if estimate < actual {
+				t.Errorf("expected an over-estimate, got %d for an actual size of %d", estimate, actual)
+			}
+
+			if limit := actual + actual/2 + 100; estimate > limit {
+				t.Errorf("expected an estimate close to the actual size %d, got %d", actual, estimate)
+			}
+		})
+	}
+}
+
+func TestEstimateStringSize(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "plain", "<&>", "\"\\\n\r\t", "\x00\x1f", "é日🚨", " ", "\xff"} {
+		encoded, _ := json.Marshal(s)
+
+		if got := estimateStringSize(s); got != len(encoded) {
+			t.Errorf("estimateStringSize(%q) = %d, expected %d", s, got, len(encoded))
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"github.com/go-resty/resty/v2"
+)
+
+// Log levels for [WithStatusLogLevels].
+const (
+	LogLevelDebug = "debug"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+	LogLevelOff   = "off"
+)
+
+// defaultStatusLogLevels returns the level used for each status family when
+// status logging is enabled: debug for 1xx to 3xx, warn for 4xx and error for
+// 5xx.
+func defaultStatusLogLevels() map[int]string {
+	return map[int]string{
+		1: LogLevelDebug,
+		2: LogLevelDebug,
+		3: LogLevelDebug,
+		4: LogLevelWarn,
+		5: LogLevelError,
+	}
+}
+
+func isValidLogLevel(level string) bool {
+	switch level {
+	case LogLevelDebug, LogLevelWarn, LogLevelError, LogLevelOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// logStatus logs the final response of a request at the level configured for
+// its status family, see [WithStatusLogging].
+func (c *Client) logStatus(response *resty.Response) {
+	level := c.options.statusLogLevels[response.StatusCode()/100]
+
+	var logf func(format string, v ...any)
+
+	switch level {
+	case LogLevelDebug:
+		logf = c.options.requestLogger.Debugf
+	case LogLevelWarn:
+		logf = c.options.requestLogger.Warnf
+	case LogLevelError:
+		logf = c.options.requestLogger.Errorf
+	default:
+		return
+	}
+
+	logf("%s %s returned status code %d", response.Request.Method, sanitizeURL(response.Request.URL), response.StatusCode())
+}
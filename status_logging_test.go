@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestStatusLogging(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("status") {
+		case "400":
+			w.WriteHeader(http.StatusBadRequest)
+		case "500":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name     string
+		status   string
+		opts     []Option
+		expected string
+	}{
+		{"2xx at debug", "200", nil, "debug: POST " + server.URL + "/alerts?status=200 returned status code 200"},
+		{"4xx at warn", "400", nil, "warn: POST " + server.URL + "/alerts?status=400 returned status code 400"},
+		{"5xx at error", "500", nil, "error: POST " + server.URL + "/alerts?status=500 returned status code 500"},
+		{"overridden level", "400", []Option{WithStatusLogLevels(map[int]string{4: LogLevelError})}, "error: POST"},
+		{"silenced family", "200", []Option{WithStatusLogLevels(map[int]string{2: LogLevelOff})}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := &recordingLogger{}
+
+			opts := append([]Option{
+				WithRequestLogger(logger),
+				WithStatusLogging(true),
+				WithRetryCount(0),
+				WithResolveQueryParam("status", tt.status),
+			}, tt.opts...)
+
+			c := New(server.URL, opts...)
+			if err := c.Connect(context.Background()); err != nil {
+				t.Fatalf("connect failed: %v", err)
+			}
+
+			_ = c.Resolve(context.Background(), &types.Alert{})
+
+			// Skip the connect-time ping.
+			var logged string
+
+			for _, msg := range strings.Split(logger.String(), "\n") {
+				if strings.Contains(msg, "/alerts") {
+					logged = msg
+				}
+			}
+
+			if tt.expected == "" {
+				if logged != "" {
+					t.Errorf("expected no log, got %q", logged)
+				}
+
+				return
+			}
+
+			if !strings.HasPrefix(logged, tt.expected) {
+				t.Errorf("expected log starting with %q, got %q", tt.expected, logged)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// streamingBodyKey is the context key of the [streamingBody] of a request.
+type streamingBodyKey struct{}
+
+// streamingBody is a request body that is encoded while it is sent, see
+// [WithStreamingEncode]. It is handed to the transport through the request
+// context rather than to resty, which would buffer a reader body in full to
+// be able to replay it.
+type streamingBody struct {
+	payload any
+	indent  bool
+}
+
+// withStreamingBody returns a copy of ctx carrying body.
+func withStreamingBody(ctx context.Context, body *streamingBody) context.Context {
+	return context.WithValue(ctx, streamingBodyKey{}, body)
+}
+
+// open returns a reader that yields a fresh encoding of the payload. The
+// encoder runs in its own goroutine and writes into a pipe, so only the
+// chunk in transit is held in memory. It stops with an error when the
+// reader is closed before the end, which the transport always does.
+func (b *streamingBody) open() io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		encoder := json.NewEncoder(pw)
+		if b.indent {
+			encoder.SetIndent("", "  ")
+		}
+
+		pw.CloseWithError(encoder.Encode(b.payload))
+	}()
+
+	return pr
+}
+
+// attachStreamingBody sets the body of req from the [streamingBody] in its
+// context, if any. GetBody opens a new encoding, so that the transport can
+// replay the body on its own, e.g. after a connection was lost before the
+// request was sent. Retries made by resty build a new request and attach a
+// new body.
+func attachStreamingBody(req *http.Request) {
+	body, ok := req.Context().Value(streamingBodyKey{}).(*streamingBody)
+	if !ok {
+		return
+	}
+
+	req.Body = body.open()
+	req.GetBody = func() (io.ReadCloser, error) {
+		return body.open(), nil
+	}
+	req.ContentLength = -1
+}
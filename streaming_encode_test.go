@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slackmgr/types"
+)
+
+func TestStreamingEncode(t *testing.T) {
+	t.Parallel()
+
+	c, recorder := newRecordingClient(t, nil, WithStreamingEncode(true), WithEnvelopeMetadata(map[string]string{"team": "payments"}))
+
+	alerts := []*types.Alert{{Text: "first <b>"}, {Text: "second"}}
+	if err := c.Send(context.Background(), alerts...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := recorder.all()
+	if len(got) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(got))
+	}
+
+	if got[0].contentLength != -1 {
+		t.Errorf("expected a chunked request without Content-Length, got %d", got[0].contentLength)
+	}
+
+	expected, err := json.Marshal(&alertsList{Alerts: alerts, Meta: c.envelopeMeta})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(got[0].body), expected) {
+		t.Errorf("expected body %s, got %s", expected, got[0].body)
+	}
+}
+
+func TestStreamingEncode_Indented(t *testing.T) {
+	t.Parallel()
+
+	c, recorder := newRecordingClient(t, nil, WithStreamingEncode(true), WithIndentedJSON(true))
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body := string(recorder.all()[0].body); !strings.Contains(body, "\n  \"alerts\": [") {
+		t.Errorf("expected an indented body, got %s", body)
+	}
+}
+
+func TestStreamingEncode_RetrySendsFullBody(t *testing.T) {
+	t.Parallel()
+
+	failFirst := func(attempt int) (int, string) {
+		if attempt == 1 {
+			return http.StatusServiceUnavailable, ""
+		}
+
+		return http.StatusOK, ""
+	}
+
+	c, recorder := newRecordingClient(t, failFirst,
+		WithStreamingEncode(true),
+		WithRetryCount(1),
+		WithRetryWaitTime(100*time.Millisecond),
+		WithRetryMaxWaitTime(100*time.Millisecond),
+	)
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := recorder.all()
+	if len(got) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(got))
+	}
+
+	if len(got[0].body) == 0 || !bytes.Equal(got[0].body, got[1].body) {
+		t.Errorf("expected the retry to send the same full body, got %q and %q", got[0].body, got[1].body)
+	}
+}
+
+func TestStreamingEncode_WithRateLimit(t *testing.T) {
+	t.Parallel()
+
+	c, recorder := newRecordingClient(t, nil, WithStreamingEncode(true), WithPerHostRateLimit(1000, 10))
+
+	if err := c.Send(context.Background(), &types.Alert{Text: "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := recorder.all(); len(got) != 1 || !bytes.Contains(got[0].body, []byte(`"text":"test"`)) {
+		t.Errorf("expected 1 request with the alert, got %v", got)
+	}
+}
+
+func TestStreamingEncode_RejectsEncodedPayloadOptions(t *testing.T) {
+	t.Parallel()
+
+	c := New("http://localhost", WithStreamingEncode(true), WithMaxInFlightBytes(1<<20))
+
+	err := c.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "streamingEncode cannot be combined") {
+		t.Errorf("expected a combination error, got %v", err)
+	}
+}
+
+func TestAttachStreamingBody(t *testing.T) {
+	t.Parallel()
+
+	body := &streamingBody{payload: map[string]string{"key": "value"}}
+
+	req, err := http.NewRequestWithContext(withStreamingBody(context.Background(), body), http.MethodPost, "http://localhost", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	attachStreamingBody(req)
+
+	first, _ := io.ReadAll(req.Body)
+
+	replay, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("GetBody failed: %v", err)
+	}
+
+	second, _ := io.ReadAll(replay)
+
+	if string(first) != "{\"key\":\"value\"}\n" || !bytes.Equal(first, second) {
+		t.Errorf("expected two full encodings, got %q and %q", first, second)
+	}
+}
+
+func TestAttachStreamingBody_WithoutBody(t *testing.T) {
+	t.Parallel()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://localhost", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	attachStreamingBody(req)
+
+	if req.Body != nil || req.GetBody != nil {
+		t.Error("expected the request to be left without a body")
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/slackmgr/types"
+)
+
+// SendTemplated expands the Go template placeholders in the Header and Text
+// of each alert with data, such as {{.host}}, and then posts the alerts like
+// [Client.Send]. Templates use the [text/template] syntax. The alerts are
+// cloned before being expanded, so the caller's values are never mutated.
+//
+// An alert whose Header or Text fails to parse or execute, including one that
+// references a key missing from data, fails the call before anything is
+// sent, with an error naming the alert index and field.
+func (c *Client) SendTemplated(ctx context.Context, data map[string]any, alerts ...*types.Alert) error {
+	if err := validateAlerts(alerts); err != nil {
+		return err
+	}
+
+	expanded := make([]*types.Alert, len(alerts))
+
+	for i, alert := range alerts {
+		clone := cloneAlert(alert)
+
+		var err error
+
+		if clone.Header, err = expandTemplate("header", alert.Header, data); err != nil {
+			return fmt.Errorf("alert at index %d: %w", i, err)
+		}
+
+		if clone.Text, err = expandTemplate("text", alert.Text, data); err != nil {
+			return fmt.Errorf("alert at index %d: %w", i, err)
+		}
+
+		expanded[i] = clone
+	}
+
+	return c.Send(ctx, expanded...)
+}
+
+// expandTemplate executes text as a template named name with data. Text
+// without any action is returned as-is.
+func expandTemplate(name, text string, data map[string]any) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var b strings.Builder
+
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to execute %s template: %w", name, err)
+	}
+
+	return b.String(), nil
+}
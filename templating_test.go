@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/slackmgr/types"
+)
+
+func TestSendTemplated(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu   sync.Mutex
+		sent []*types.Alert
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/alerts" {
+			body, _ := io.ReadAll(r.Body)
+
+			var payload alertsList
+			_ = json.Unmarshal(body, &payload)
+
+			mu.Lock()
+			sent = append(sent, payload.Alerts...)
+			mu.Unlock()
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	c := New(server.URL, WithRetryCount(0))
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+
+	t.Run("expands header and text", func(t *testing.T) {
+		alert := &types.Alert{Header: "Disk full on {{.host}}", Text: "{{.used}}% used", Author: "{{.host}}"}
+
+		err := c.SendTemplated(context.Background(), map[string]any{"host": "db-1", "used": 97}, alert)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(sent) != 1 {
+			t.Fatalf("expected 1 alert to be sent, got %d", len(sent))
+		}
+
+		if sent[0].Header != "Disk full on db-1" || sent[0].Text != "97% used" {
+			t.Errorf("unexpected expansion: header=%q text=%q", sent[0].Header, sent[0].Text)
+		}
+
+		if sent[0].Author != "{{.host}}" {
+			t.Errorf("expected other fields to be left as-is, got author=%q", sent[0].Author)
+		}
+
+		if alert.Header != "Disk full on {{.host}}" || alert.Text != "{{.used}}% used" {
+			t.Error("expected the original alert not to be mutated")
+		}
+	})
+
+	t.Run("parse error names the alert", func(t *testing.T) {
+		err := c.SendTemplated(context.Background(), nil,
+			&types.Alert{Header: "ok"},
+			&types.Alert{Header: "ok", Text: "{{.host"},
+		)
+		if err == nil || !strings.Contains(err.Error(), "alert at index 1: failed to parse text template") {
+			t.Errorf("expected a parse error for alert 1, got: %v", err)
+		}
+	})
+
+	t.Run("execution error names the alert", func(t *testing.T) {
+		err := c.SendTemplated(context.Background(), map[string]any{"host": "db-1"},
+			&types.Alert{Header: "{{.host.name}}"},
+		)
+		if err == nil || !strings.Contains(err.Error(), "alert at index 0: failed to execute header template") {
+			t.Errorf("expected an execution error for alert 0, got: %v", err)
+		}
+	})
+
+	t.Run("missing key names the alert", func(t *testing.T) {
+		err := c.SendTemplated(context.Background(), map[string]any{"host": "db-1"},
+			&types.Alert{Header: "{{.host}}"},
+			&types.Alert{Header: "{{.host}}", Text: "{{.region}}"},
+		)
+		if err == nil || !strings.Contains(err.Error(), "alert at index 1: failed to execute text template") {
+			t.Errorf("expected a missing key error for alert 1, got: %v", err)
+		}
+	})
+
+	t.Run("nil alert", func(t *testing.T) {
+		if err := c.SendTemplated(context.Background(), nil, nil); err == nil {
+			t.Error("expected error for nil alert")
+		}
+	})
+}
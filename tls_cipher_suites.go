@@ -0,0 +1,39 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"slices"
+)
+
+// checkCipherSuite returns an error unless id is one of the secure cipher
+// suites implemented by crypto/tls.
+func checkCipherSuite(id uint16) error {
+	for _, suite := range tls.CipherSuites() {
+		if suite.ID == id {
+			return nil
+		}
+	}
+
+	for _, suite := range tls.InsecureCipherSuites() {
+		if suite.ID == id {
+			return fmt.Errorf("cipher suite %s is insecure", suite.Name)
+		}
+	}
+
+	return fmt.Errorf("unknown cipher suite 0x%04x", id)
+}
+
+// cipherSuitesTLSConfig returns a copy of config (which may be nil) that only
+// negotiates the given cipher suites.
+func cipherSuitesTLSConfig(config *tls.Config, ids []uint16) *tls.Config {
+	if config == nil {
+		config = &tls.Config{} //nolint:gosec // MinVersion defaults to TLS 1.2 for clients
+	} else {
+		config = config.Clone()
+	}
+
+	config.CipherSuites = slices.Clone(ids)
+
+	return config
+}
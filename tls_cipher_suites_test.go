@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCheckCipherSuite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		id        uint16
+		wantError string
+	}{
+		{"secure", tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, ""},
+		{"TLS 1.3", tls.TLS_AES_128_GCM_SHA256, ""},
+		{"insecure", tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA, "cipher suite TLS_RSA_WITH_3DES_EDE_CBC_SHA is insecure"},
+		{"unknown", 0xffff, "unknown cipher suite 0xffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkCipherSuite(tt.id)
+
+			switch {
+			case tt.wantError == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantError != "" && (err == nil || err.Error() != tt.wantError):
+				t.Errorf("expected error %q, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestCipherSuitesTLSConfig(t *testing.T) {
+	t.Parallel()
+
+	base := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: "alerts.example.com"}
+	ids := []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+
+	config := cipherSuitesTLSConfig(base, ids)
+
+	if config.MinVersion != tls.VersionTLS12 || config.ServerName != "alerts.example.com" {
+		t.Errorf("expected the base configuration to be kept, got %+v", config)
+	}
+
+	if len(config.CipherSuites) != 1 || config.CipherSuites[0] != ids[0] {
+		t.Errorf("expected the cipher suites to be set, got %v", config.CipherSuites)
+	}
+
+	if base.CipherSuites != nil {
+		t.Error("expected the base configuration not to be modified")
+	}
+
+	if config := cipherSuitesTLSConfig(nil, ids); len(config.CipherSuites) != 1 {
+		t.Errorf("expected a configuration to be created, got %v", config.CipherSuites)
+	}
+}
+
+func TestConnect_TLSCipherSuites(t *testing.T) {
+	t.Parallel()
+
+	var negotiated atomic.Uint32
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		negotiated.Store(uint32(r.TLS.CipherSuite))
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	t.Run("restricted to a shared suite", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithTLSConfig(tlsConfig), WithTLSCipherSuites(tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384))
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect failed: %v", err)
+		}
+
+		if got := uint16(negotiated.Load()); got != tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384 {
+			t.Errorf("expected TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384, got %s", tls.CipherSuiteName(got))
+		}
+	})
+
+	t.Run("no shared suite", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithTLSConfig(tlsConfig), WithRetryCount(0), WithTLSCipherSuites(tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256))
+		if err := c.Connect(context.Background()); err == nil {
+			t.Error("expected the handshake to fail")
+		}
+	})
+
+	t.Run("insecure suite rejected", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(server.URL, WithTLSConfig(tlsConfig), WithTLSCipherSuites(tls.TLS_RSA_WITH_RC4_128_SHA))
+		if err := c.Connect(context.Background()); err == nil {
+			t.Error("expected an invalid options error")
+		}
+	})
+}